@@ -2,9 +2,16 @@ package goalphavantage
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 )
 
 type Topic string
@@ -85,6 +92,175 @@ type NewsSentimentResponse struct {
 	Feed                     []*NewsFeed `json:"feed"`
 }
 
+type SentimentLabel string
+
+const (
+	SentimentBearish         SentimentLabel = "Bearish"
+	SentimentSomewhatBearish SentimentLabel = "Somewhat-Bearish"
+	SentimentNeutral         SentimentLabel = "Neutral"
+	SentimentSomewhatBullish SentimentLabel = "Somewhat-Bullish"
+	SentimentBullish         SentimentLabel = "Bullish"
+)
+
+func (s SentimentLabel) Valid() bool {
+	switch s {
+	case SentimentBearish, SentimentSomewhatBearish, SentimentNeutral, SentimentSomewhatBullish, SentimentBullish:
+		return true
+	default:
+		return false
+	}
+}
+
+func (s *SentimentLabel) UnmarshalText(text []byte) error {
+	// Labels are reported as both "Somewhat-Bullish" and "Somewhat_Bullish"
+	for _, label := range []SentimentLabel{SentimentBearish, SentimentSomewhatBearish, SentimentNeutral, SentimentSomewhatBullish, SentimentBullish} {
+		if normalizeRecordKey(string(label)) == normalizeRecordKey(string(text)) {
+			*s = label
+			return nil
+		}
+	}
+	*s = SentimentLabel(text)
+	return nil
+}
+
+// Thresholds follow the sentiment_score_definition returned by NEWS_SENTIMENT
+func SentimentLabelForScore(score float64) SentimentLabel {
+	switch {
+	case score <= -0.35:
+		return SentimentBearish
+	case score <= -0.15:
+		return SentimentSomewhatBearish
+	case score < 0.15:
+		return SentimentNeutral
+	case score < 0.35:
+		return SentimentSomewhatBullish
+	default:
+		return SentimentBullish
+	}
+}
+
+type ArticleTopic struct {
+	Topic          string  `json:"topic"`
+	RelevanceScore float64 `json:"relevance_score"`
+}
+
+func (a *ArticleTopic) UnmarshalJSON(data []byte) error {
+	var topic ArticleTopic
+	if err := decodeJSONRecord(data, &topic); err != nil {
+		return err
+	}
+	*a = topic
+	return nil
+}
+
+type ArticleTickerSentiment struct {
+	Ticker         string         `json:"ticker"`
+	RelevanceScore float64        `json:"relevance_score"`
+	SentimentScore float64        `json:"ticker_sentiment_score"`
+	SentimentLabel SentimentLabel `json:"ticker_sentiment_label"`
+}
+
+func (a *ArticleTickerSentiment) UnmarshalJSON(data []byte) error {
+	var sentiment ArticleTickerSentiment
+	if err := decodeJSONRecord(data, &sentiment); err != nil {
+		return err
+	}
+	*a = sentiment
+	return nil
+}
+
+type Article struct {
+	Title                 string                   `json:"title"`
+	URL                   string                   `json:"url"`
+	TimePublished         time.Time                `json:"-"`
+	Authors               []string                 `json:"authors"`
+	Summary               string                   `json:"summary"`
+	BannerImage           string                   `json:"banner_image"`
+	Source                string                   `json:"source"`
+	CategoryWithinSource  string                   `json:"category_within_source"`
+	SourceDomain          string                   `json:"source_domain"`
+	Topics                []ArticleTopic           `json:"topics"`
+	OverallSentimentScore float64                  `json:"overall_sentiment_score"`
+	OverallSentimentLabel SentimentLabel           `json:"overall_sentiment_label"`
+	TickerSentiment       []ArticleTickerSentiment `json:"ticker_sentiment"`
+}
+
+func (a *Article) UnmarshalJSON(data []byte) error {
+	var article Article
+	if err := decodeJSONRecord(data, &article); err != nil {
+		return err
+	}
+
+	var published struct {
+		TimePublished string `json:"time_published"`
+	}
+	if err := json.Unmarshal(data, &published); err != nil {
+		return err
+	}
+	if !isNullValue(published.TimePublished) {
+		var err error
+		if article.TimePublished, err = parseEasternTimestamp(published.TimePublished); err != nil {
+			return fmt.Errorf("invalid time_published %q: %w", published.TimePublished, err)
+		}
+	}
+
+	*a = article
+	return nil
+}
+
+func (a Article) Ticker(ticker string) (ArticleTickerSentiment, bool) {
+	for _, sentiment := range a.TickerSentiment {
+		if strings.EqualFold(sentiment.Ticker, ticker) {
+			return sentiment, true
+		}
+	}
+	return ArticleTickerSentiment{}, false
+}
+
+type NewsArticlesResponse struct {
+	Items                    int       `json:"items"`
+	SentimentScoreDefinition string    `json:"sentiment_score_definition"`
+	RelevanceScoreDefinition string    `json:"relevance_score_definition"`
+	Feed                     []Article `json:"feed"`
+}
+
+func (n *NewsArticlesResponse) UnmarshalJSON(data []byte) error {
+	var res NewsArticlesResponse
+	if err := decodeJSONRecord(data, &res); err != nil {
+		return err
+	}
+	*n = res
+	return nil
+}
+
+func (n *NewsFeed) Typed() (Article, error) {
+	content, err := json.Marshal(n)
+	if err != nil {
+		return Article{}, err
+	}
+
+	var article Article
+	if err = json.Unmarshal(content, &article); err != nil {
+		return Article{}, err
+	}
+	return article, nil
+}
+
+func (n *NewsSentimentResponse) Articles() ([]Article, error) {
+	articles := make([]Article, 0, len(n.Feed))
+	for _, feed := range n.Feed {
+		if feed == nil {
+			continue
+		}
+		article, err := feed.Typed()
+		if err != nil {
+			return nil, err
+		}
+		articles = append(articles, article)
+	}
+	return articles, nil
+}
+
 type RankedStock struct {
 	Ticker           *string `json:"ticker"`
 	Price            *string `json:"price"`
@@ -123,6 +299,328 @@ func (c *Client) GetNewsSentiment(ctx context.Context, options *NewsSentimentOpt
 	return &res, nil
 }
 
+func (c *Client) GetNewsArticles(ctx context.Context, options *NewsSentimentOptions) (*NewsArticlesResponse, error) {
+	if !options.Valid() {
+		return nil, InValidInputError
+	}
+
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%sfunction=NEWS_SENTIMENT&%s", c.BaseURL, c.buildQuery(options)), nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	var res NewsArticlesResponse
+	if err = c.doJSONRequest(req, &res); err != nil {
+		return nil, fmt.Errorf("failed to get news articles: %w", err)
+	}
+	return &res, nil
+}
+
+const maxNewsSentimentLimit = 1000
+
+var defaultNewsSentimentWindow = 7 * 24 * time.Hour
+
+type NewsSentimentRangeOptions struct {
+	Tickers []string
+	Topics  []Topic
+	From    time.Time
+	To      time.Time
+	Window  time.Duration
+	Limit   int
+}
+
+func (n NewsSentimentRangeOptions) Valid() bool {
+	if n.From.IsZero() || !n.To.After(n.From) || n.Window < 0 || n.Limit < 0 || n.Limit > maxNewsSentimentLimit {
+		return false
+	}
+
+	for _, topic := range n.Topics {
+		if !topic.Valid() {
+			return false
+		}
+	}
+	return true
+}
+
+func formatNewsTime(t time.Time) (string, error) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		return "", err
+	}
+	return t.In(loc).Format("20060102T1504"), nil
+}
+
+type NewsSentimentIterator struct {
+	client  *Client
+	options NewsSentimentRangeOptions
+	current time.Time
+	window  time.Duration
+	pending []*NewsFeed
+	article *NewsFeed
+	seen    map[string]bool
+	err     error
+}
+
+func (c *Client) NewsSentimentRange(options NewsSentimentRangeOptions) *NewsSentimentIterator {
+	iterator := &NewsSentimentIterator{
+		client:  c,
+		options: options,
+		current: options.From.Truncate(time.Minute),
+		window:  options.Window,
+		seen:    make(map[string]bool),
+	}
+
+	if !options.Valid() {
+		iterator.err = InValidInputError
+	}
+	if iterator.window == 0 {
+		iterator.window = defaultNewsSentimentWindow
+	}
+	if iterator.options.Limit == 0 {
+		iterator.options.Limit = maxNewsSentimentLimit
+	}
+	return iterator
+}
+
+func (n *NewsSentimentIterator) Next(ctx context.Context) bool {
+	for n.err == nil {
+		if len(n.pending) > 0 {
+			n.article = n.pending[0]
+			n.pending = n.pending[1:]
+			return true
+		}
+
+		if !n.current.Before(n.options.To) {
+			return false
+		}
+
+		if err := ctx.Err(); err != nil {
+			n.err = err
+			return false
+		}
+
+		n.err = n.fetchWindow(ctx)
+	}
+	return false
+}
+
+func (n *NewsSentimentIterator) fetchWindow(ctx context.Context) error {
+	end := n.current.Add(n.window)
+	if end.After(n.options.To) {
+		end = n.options.To
+	}
+
+	timeFrom, err := formatNewsTime(n.current)
+	if err != nil {
+		return err
+	}
+	timeTo, err := formatNewsTime(end)
+	if err != nil {
+		return err
+	}
+
+	res, err := n.client.GetNewsSentiment(ctx, &NewsSentimentOptions{
+		Tickers:  n.options.Tickers,
+		Topics:   n.options.Topics,
+		TimeFrom: timeFrom,
+		TimeTo:   timeTo,
+		Sort:     "EARLIEST",
+		Limit:    n.options.Limit,
+	})
+
+	var apiErr *APIError
+	switch {
+	// Windows without any articles come back as an informational message
+	case errors.As(err, &apiErr) && strings.Contains(strings.ToLower(apiErr.Message), "no articles"):
+		res = &NewsSentimentResponse{}
+	case err != nil:
+		return err
+	}
+
+	// A full page means articles were cut off, so retry with a narrower window
+	if len(res.Feed) >= n.options.Limit {
+		// Timestamps only have minute precision, so a one minute window cannot be split any further
+		if n.window <= time.Minute {
+			return fmt.Errorf("news window starting at %s returned a full page of %d articles, so some articles cannot be fetched", timeFrom, n.options.Limit)
+		}
+		n.window = (n.window / 2).Truncate(time.Minute)
+		if n.window < time.Minute {
+			n.window = time.Minute
+		}
+		return nil
+	}
+
+	feed := make([]*NewsFeed, 0, len(res.Feed))
+	for _, article := range res.Feed {
+		if article == nil {
+			continue
+		}
+		if article.URL != nil {
+			if n.seen[*article.URL] {
+				continue
+			}
+			n.seen[*article.URL] = true
+		}
+		feed = append(feed, article)
+	}
+
+	sort.SliceStable(feed, func(i, j int) bool {
+		return newsTimePublished(feed[i]) < newsTimePublished(feed[j])
+	})
+
+	n.pending = feed
+	n.current = end
+	if len(res.Feed) < n.options.Limit/2 && n.window < n.initialWindow() {
+		n.window *= 2
+	}
+	return nil
+}
+
+func (n *NewsSentimentIterator) initialWindow() time.Duration {
+	if n.options.Window == 0 {
+		return defaultNewsSentimentWindow
+	}
+	return n.options.Window
+}
+
+func newsTimePublished(article *NewsFeed) string {
+	if article.TimePublished == nil {
+		return ""
+	}
+	return *article.TimePublished
+}
+
+func (n *NewsSentimentIterator) Article() *NewsFeed {
+	return n.article
+}
+
+func (n *NewsSentimentIterator) Err() error {
+	return n.err
+}
+
+type SentimentBucket string
+
+const (
+	SentimentBucketHourly SentimentBucket = "hourly"
+	SentimentBucketDaily  SentimentBucket = "daily"
+	SentimentBucketWeekly SentimentBucket = "weekly"
+)
+
+func (s SentimentBucket) Valid() bool {
+	switch s {
+	case SentimentBucketHourly, SentimentBucketDaily, SentimentBucketWeekly:
+		return true
+	default:
+		return false
+	}
+}
+
+func (s SentimentBucket) start(t time.Time) time.Time {
+	switch s {
+	case SentimentBucketHourly:
+		return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, t.Location())
+	case SentimentBucketWeekly:
+		// Weeks start on Monday to line up with weekly bars
+		offset := (int(t.Weekday()) + 6) % 7
+		return time.Date(t.Year(), t.Month(), t.Day()-offset, 0, 0, 0, 0, t.Location())
+	default:
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	}
+}
+
+type SentimentAggregationOptions struct {
+	Bucket       SentimentBucket
+	MinRelevance float64
+	Location     *time.Location
+}
+
+func (s SentimentAggregationOptions) Valid() bool {
+	return s.Bucket.Valid() && s.MinRelevance >= 0 && s.MinRelevance <= 1
+}
+
+type SentimentPoint struct {
+	Ticker         string
+	Start          time.Time
+	Articles       int
+	Score          float64
+	TotalRelevance float64
+	Labels         map[SentimentLabel]int
+}
+
+func (s SentimentPoint) Label() SentimentLabel {
+	return SentimentLabelForScore(s.Score)
+}
+
+type SentimentSeries map[string][]SentimentPoint
+
+func (s SentimentSeries) Ticker(ticker string) []SentimentPoint {
+	return s[strings.ToUpper(ticker)]
+}
+
+func AggregateTickerSentiment(articles []Article, options SentimentAggregationOptions) (SentimentSeries, error) {
+	if !options.Valid() {
+		return nil, InValidInputError
+	}
+
+	loc := options.Location
+	if loc == nil {
+		var err error
+		if loc, err = time.LoadLocation("America/New_York"); err != nil {
+			return nil, err
+		}
+	}
+
+	points := make(map[string]map[int64]*SentimentPoint)
+	for _, article := range articles {
+		if article.TimePublished.IsZero() {
+			continue
+		}
+		start := options.Bucket.start(article.TimePublished.In(loc))
+
+		for _, sentiment := range article.TickerSentiment {
+			if sentiment.RelevanceScore <= 0 || sentiment.RelevanceScore < options.MinRelevance {
+				continue
+			}
+
+			ticker := strings.ToUpper(sentiment.Ticker)
+			if points[ticker] == nil {
+				points[ticker] = make(map[int64]*SentimentPoint)
+			}
+			point, ok := points[ticker][start.Unix()]
+			if !ok {
+				point = &SentimentPoint{Ticker: ticker, Start: start, Labels: make(map[SentimentLabel]int)}
+				points[ticker][start.Unix()] = point
+			}
+
+			label := sentiment.SentimentLabel
+			if !label.Valid() {
+				label = SentimentLabelForScore(sentiment.SentimentScore)
+			}
+
+			point.Articles++
+			point.Labels[label]++
+			// Score holds the relevance weighted sum until it is normalized below
+			point.Score += sentiment.SentimentScore * sentiment.RelevanceScore
+			point.TotalRelevance += sentiment.RelevanceScore
+		}
+	}
+
+	series := make(SentimentSeries, len(points))
+	for ticker, buckets := range points {
+		tickerSeries := make([]SentimentPoint, 0, len(buckets))
+		for _, point := range buckets {
+			point.Score /= point.TotalRelevance
+			tickerSeries = append(tickerSeries, *point)
+		}
+
+		sort.Slice(tickerSeries, func(i, j int) bool {
+			return tickerSeries[i].Start.Before(tickerSeries[j].Start)
+		})
+		series[ticker] = tickerSeries
+	}
+	return series, nil
+}
+
 func (c *Client) GetTopGainersLosers(ctx context.Context) (*RankingResponse, error) {
 	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%sfunction=TOP_GAINERS_LOSERS", c.BaseURL), nil)
 	if err != nil {
@@ -135,3 +633,813 @@ func (c *Client) GetTopGainersLosers(ctx context.Context) (*RankingResponse, err
 	}
 	return &res, nil
 }
+
+type SpeakerRole string
+
+const (
+	SpeakerRoleOperator  SpeakerRole = "operator"
+	SpeakerRoleExecutive SpeakerRole = "executive"
+	SpeakerRoleAnalyst   SpeakerRole = "analyst"
+	SpeakerRoleOther     SpeakerRole = "other"
+)
+
+var earliestTranscriptYear = 2010
+
+func validTranscriptQuarter(quarter string) bool {
+	//Check for YYYYQn
+	quarterRegex := regexp.MustCompile(`^(\d{4})Q[1-4]$`)
+	match := quarterRegex.FindStringSubmatch(quarter)
+	if match == nil {
+		return false
+	}
+
+	year, err := strconv.Atoi(match[1])
+	if err != nil {
+		return false
+	}
+	return year >= earliestTranscriptYear
+}
+
+type TranscriptSegment struct {
+	Speaker   string   `json:"speaker"`
+	Title     string   `json:"title"`
+	Content   string   `json:"content"`
+	Sentiment *float64 `json:"sentiment"`
+}
+
+func (t *TranscriptSegment) UnmarshalJSON(data []byte) error {
+	var segment TranscriptSegment
+	if err := decodeJSONRecord(data, &segment); err != nil {
+		return err
+	}
+	*t = segment
+	return nil
+}
+
+func (t TranscriptSegment) Role() SpeakerRole {
+	title := strings.ToLower(t.Title)
+	switch {
+	case strings.EqualFold(t.Speaker, "operator") || strings.Contains(title, "operator"):
+		return SpeakerRoleOperator
+	case strings.Contains(title, "analyst"):
+		return SpeakerRoleAnalyst
+	}
+
+	for _, keyword := range []string{"chief", "ceo", "cfo", "coo", "cto", "president", "vp", "officer", "chairman", "director", "head", "investor relations", "treasurer", "controller"} {
+		if strings.Contains(title, keyword) {
+			return SpeakerRoleExecutive
+		}
+	}
+	return SpeakerRoleOther
+}
+
+type EarningsCallTranscript struct {
+	Symbol     string              `json:"symbol"`
+	Quarter    string              `json:"quarter"`
+	Transcript []TranscriptSegment `json:"transcript"`
+}
+
+func (e *EarningsCallTranscript) ByRole(role SpeakerRole) []TranscriptSegment {
+	var segments []TranscriptSegment
+	for _, segment := range e.Transcript {
+		if segment.Role() == role {
+			segments = append(segments, segment)
+		}
+	}
+	return segments
+}
+
+func (e *EarningsCallTranscript) BySpeaker(speaker string) []TranscriptSegment {
+	var segments []TranscriptSegment
+	for _, segment := range e.Transcript {
+		if strings.EqualFold(strings.TrimSpace(segment.Speaker), strings.TrimSpace(speaker)) {
+			segments = append(segments, segment)
+		}
+	}
+	return segments
+}
+
+func (e *EarningsCallTranscript) FullText() string {
+	var builder strings.Builder
+	for i, segment := range e.Transcript {
+		if i > 0 {
+			builder.WriteString("\n\n")
+		}
+		builder.WriteString(segment.Speaker)
+		builder.WriteString(": ")
+		builder.WriteString(strings.TrimSpace(segment.Content))
+	}
+	return builder.String()
+}
+
+type earningsCallTranscriptOptions struct {
+	Symbol  string `url:"symbol"`
+	Quarter string `url:"quarter"`
+}
+
+func (c *Client) GetEarningsCallTranscript(ctx context.Context, symbol string, quarter string) (*EarningsCallTranscript, error) {
+	if strings.TrimSpace(symbol) == "" || !validTranscriptQuarter(quarter) {
+		return nil, InValidInputError
+	}
+	options := earningsCallTranscriptOptions{Symbol: symbol, Quarter: quarter}
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%sfunction=EARNINGS_CALL_TRANSCRIPT&%s", c.BaseURL, c.buildQuery(options)), nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	var res EarningsCallTranscript
+	if err = c.doJSONRequest(req, &res); err != nil {
+		return nil, fmt.Errorf("failed to get earnings call transcript: %w", err)
+	}
+	return &res, nil
+}
+
+type InsiderTransactionType string
+
+const (
+	InsiderAcquisition InsiderTransactionType = "A"
+	InsiderDisposal    InsiderTransactionType = "D"
+)
+
+type InsiderTransaction struct {
+	TransactionDate       time.Time              `json:"transaction_date"`
+	Ticker                string                 `json:"ticker"`
+	Executive             string                 `json:"executive"`
+	ExecutiveTitle        string                 `json:"executive_title"`
+	SecurityType          string                 `json:"security_type"`
+	AcquisitionOrDisposal InsiderTransactionType `json:"acquisition_or_disposal"`
+	Shares                float64                `json:"shares"`
+	SharePrice            *float64               `json:"share_price"`
+}
+
+func (i *InsiderTransaction) UnmarshalJSON(data []byte) error {
+	var transaction InsiderTransaction
+	if err := decodeJSONRecord(data, &transaction); err != nil {
+		return err
+	}
+	transaction.AcquisitionOrDisposal = InsiderTransactionType(strings.ToUpper(string(transaction.AcquisitionOrDisposal)))
+	*i = transaction
+	return nil
+}
+
+func (i InsiderTransaction) Value() float64 {
+	if i.SharePrice == nil {
+		return 0
+	}
+	return i.Shares * *i.SharePrice
+}
+
+type InsiderTransactionsResponse struct {
+	Data []InsiderTransaction `json:"data"`
+}
+
+type InsiderSummary struct {
+	SharesBought float64
+	SharesSold   float64
+	ValueBought  float64
+	ValueSold    float64
+	Transactions int
+}
+
+func (i *InsiderSummary) add(transaction InsiderTransaction) {
+	switch transaction.AcquisitionOrDisposal {
+	case InsiderAcquisition:
+		i.SharesBought += transaction.Shares
+		i.ValueBought += transaction.Value()
+	case InsiderDisposal:
+		i.SharesSold += transaction.Shares
+		i.ValueSold += transaction.Value()
+	}
+	i.Transactions++
+}
+
+func (i InsiderSummary) NetShares() float64 {
+	return i.SharesBought - i.SharesSold
+}
+
+func (i InsiderSummary) NetValue() float64 {
+	return i.ValueBought - i.ValueSold
+}
+
+type InsiderWindowSummary struct {
+	Start    time.Time
+	End      time.Time
+	Insiders int
+	InsiderSummary
+}
+
+func SummarizeInsidersByExecutive(transactions []InsiderTransaction) map[string]InsiderSummary {
+	summaries := make(map[string]InsiderSummary)
+	for _, transaction := range transactions {
+		summary := summaries[transaction.Executive]
+		summary.add(transaction)
+		summaries[transaction.Executive] = summary
+	}
+	return summaries
+}
+
+func SummarizeInsidersByWindow(transactions []InsiderTransaction, days int) []InsiderWindowSummary {
+	if days <= 0 || len(transactions) == 0 {
+		return nil
+	}
+
+	sorted := make([]InsiderTransaction, len(transactions))
+	copy(sorted, transactions)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].TransactionDate.Before(sorted[j].TransactionDate)
+	})
+
+	var summaries []InsiderWindowSummary
+	start := 0
+	for end := 0; end < len(sorted); end++ {
+		windowEnd := sorted[end].TransactionDate
+		if end+1 < len(sorted) && sorted[end+1].TransactionDate.Equal(windowEnd) {
+			continue
+		}
+
+		windowStart := windowEnd.AddDate(0, 0, 1-days)
+		for sorted[start].TransactionDate.Before(windowStart) {
+			start++
+		}
+
+		summary := InsiderWindowSummary{Start: windowStart, End: windowEnd}
+		insiders := make(map[string]bool)
+		for _, transaction := range sorted[start : end+1] {
+			summary.add(transaction)
+			insiders[transaction.Executive] = true
+		}
+		summary.Insiders = len(insiders)
+		summaries = append(summaries, summary)
+	}
+	return summaries
+}
+
+func (r *InsiderTransactionsResponse) SummaryByExecutive() map[string]InsiderSummary {
+	return SummarizeInsidersByExecutive(r.Data)
+}
+
+func (r *InsiderTransactionsResponse) SummaryByWindow(days int) []InsiderWindowSummary {
+	return SummarizeInsidersByWindow(r.Data, days)
+}
+
+func (c *Client) GetInsiderTransactions(ctx context.Context, symbol string) (*InsiderTransactionsResponse, error) {
+	if strings.TrimSpace(symbol) == "" {
+		return nil, InValidInputError
+	}
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%sfunction=INSIDER_TRANSACTIONS&symbol=%s", c.BaseURL, url.QueryEscape(symbol)), nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	var res InsiderTransactionsResponse
+	if err = c.doJSONRequest(req, &res); err != nil {
+		return nil, fmt.Errorf("failed to get insider transactions: %w", err)
+	}
+	return &res, nil
+}
+
+type Calculation string
+type CorrelationMethod string
+type OHLCField string
+type AnalyticsInterval string
+
+const (
+	CalculationMin              Calculation = "MIN"
+	CalculationMax              Calculation = "MAX"
+	CalculationMean             Calculation = "MEAN"
+	CalculationMedian           Calculation = "MEDIAN"
+	CalculationCumulativeReturn Calculation = "CUMULATIVE_RETURN"
+	CalculationVariance         Calculation = "VARIANCE"
+	CalculationStdDev           Calculation = "STDDEV"
+	CalculationMaxDrawdown      Calculation = "MAX_DRAWDOWN"
+	CalculationHistogram        Calculation = "HISTOGRAM"
+	CalculationAutocorrelation  Calculation = "AUTOCORRELATION"
+	CalculationCovariance       Calculation = "COVARIANCE"
+	CalculationCorrelation      Calculation = "CORRELATION"
+
+	CorrelationPearson  CorrelationMethod = "PEARSON"
+	CorrelationKendall  CorrelationMethod = "KENDALL"
+	CorrelationSpearman CorrelationMethod = "SPEARMAN"
+)
+
+func VarianceCalculation(annualized bool) Calculation {
+	return Calculation(fmt.Sprintf("%s(annualized=%s)", CalculationVariance, pythonBool(annualized)))
+}
+
+func StdDevCalculation(annualized bool) Calculation {
+	return Calculation(fmt.Sprintf("%s(annualized=%s)", CalculationStdDev, pythonBool(annualized)))
+}
+
+func CovarianceCalculation(annualized bool) Calculation {
+	return Calculation(fmt.Sprintf("%s(annualized=%s)", CalculationCovariance, pythonBool(annualized)))
+}
+
+func HistogramCalculation(bins int) Calculation {
+	return Calculation(fmt.Sprintf("%s(bins=%d)", CalculationHistogram, bins))
+}
+
+func AutocorrelationCalculation(lag int) Calculation {
+	return Calculation(fmt.Sprintf("%s(lag=%d)", CalculationAutocorrelation, lag))
+}
+
+func CorrelationCalculation(method CorrelationMethod) Calculation {
+	return Calculation(fmt.Sprintf("%s(method=%s)", CalculationCorrelation, strings.ToUpper(string(method))))
+}
+
+func pythonBool(value bool) string {
+	if value {
+		return "True"
+	}
+	return "False"
+}
+
+func (c Calculation) Name() Calculation {
+	name, _, _ := strings.Cut(string(c), "(")
+	return Calculation(strings.ToUpper(strings.TrimSpace(name)))
+}
+
+func (c Calculation) key() string {
+	return strings.ToUpper(strings.ReplaceAll(string(c), " ", ""))
+}
+
+func (c Calculation) Valid() bool {
+	switch c.Name() {
+	case CalculationMin, CalculationMax, CalculationMean, CalculationMedian, CalculationCumulativeReturn, CalculationVariance, CalculationStdDev, CalculationMaxDrawdown, CalculationHistogram, CalculationAutocorrelation, CalculationCovariance, CalculationCorrelation:
+		return true
+	default:
+		return false
+	}
+}
+
+func (c Calculation) validForSlidingWindow() bool {
+	switch c.Name() {
+	case CalculationMean, CalculationMedian, CalculationCumulativeReturn, CalculationVariance, CalculationStdDev, CalculationCovariance, CalculationCorrelation:
+		return true
+	default:
+		return false
+	}
+}
+
+func (c CorrelationMethod) Valid() bool {
+	switch strings.ToUpper(string(c)) {
+	case "PEARSON", "KENDALL", "SPEARMAN":
+		return true
+	default:
+		return false
+	}
+}
+
+func (o OHLCField) Valid() bool {
+	switch strings.ToLower(string(o)) {
+	case "", "open", "high", "low", "close":
+		return true
+	default:
+		return false
+	}
+}
+
+func (a AnalyticsInterval) Valid() bool {
+	switch strings.ToUpper(string(a)) {
+	case "1MIN", "5MIN", "15MIN", "30MIN", "60MIN", "DAILY", "WEEKLY", "MONTHLY":
+		return true
+	default:
+		return false
+	}
+}
+
+type AnalyticsRange struct {
+	Start    time.Time
+	End      time.Time
+	Relative string
+}
+
+func (a AnalyticsRange) Valid() bool {
+	if a.Relative != "" {
+		relativeRegex := regexp.MustCompile(`^(full|\d+(minute|hour|day|week|month|year))$`)
+		return a.Start.IsZero() && a.End.IsZero() && relativeRegex.MatchString(strings.ToLower(a.Relative))
+	}
+	if a.Start.IsZero() {
+		return false
+	}
+	return a.End.IsZero() || a.End.After(a.Start)
+}
+
+func formatAnalyticsTime(t time.Time) string {
+	if t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0 {
+		return t.Format("2006-01-02")
+	}
+	return t.Format("2006-01-02T15:04:05")
+}
+
+func (a AnalyticsRange) values() []string {
+	if a.Relative != "" {
+		return []string{strings.ToLower(a.Relative)}
+	}
+
+	values := []string{formatAnalyticsTime(a.Start)}
+	if !a.End.IsZero() {
+		values = append(values, formatAnalyticsTime(a.End))
+	}
+	return values
+}
+
+func validAnalyticsInput(symbols []string, analyticsRange AnalyticsRange, interval AnalyticsInterval, ohlc OHLCField, calculations []Calculation) bool {
+	if len(symbols) == 0 || len(calculations) == 0 {
+		return false
+	}
+	for _, symbol := range symbols {
+		if strings.TrimSpace(symbol) == "" {
+			return false
+		}
+	}
+	for _, calculation := range calculations {
+		if !calculation.Valid() {
+			return false
+		}
+	}
+	return analyticsRange.Valid() && interval.Valid() && ohlc.Valid()
+}
+
+func (a AnalyticsFixedWindowOptions) Valid() bool {
+	return validAnalyticsInput(a.Symbols, a.Range, a.Interval, a.OHLC, a.Calculations)
+}
+
+func (a AnalyticsSlidingWindowOptions) Valid() bool {
+	if !validAnalyticsInput(a.Symbols, a.Range, a.Interval, a.OHLC, a.Calculations) || a.WindowSize < 10 {
+		return false
+	}
+	for _, calculation := range a.Calculations {
+		if !calculation.validForSlidingWindow() {
+			return false
+		}
+	}
+	return true
+}
+
+type AnalyticsFixedWindowOptions struct {
+	Symbols      []string
+	Range        AnalyticsRange
+	Interval     AnalyticsInterval
+	OHLC         OHLCField
+	Calculations []Calculation
+}
+
+type AnalyticsSlidingWindowOptions struct {
+	Symbols      []string
+	Range        AnalyticsRange
+	Interval     AnalyticsInterval
+	OHLC         OHLCField
+	WindowSize   int
+	Calculations []Calculation
+}
+
+type analyticsQuery struct {
+	Symbols      string   `url:"SYMBOLS"`
+	Range        []string `url:"RANGE"`
+	Interval     string   `url:"INTERVAL"`
+	OHLC         string   `url:"OHLC,omitempty"`
+	WindowSize   int      `url:"WINDOW_SIZE,omitempty"`
+	Calculations string   `url:"CALCULATIONS"`
+}
+
+func newAnalyticsQuery(symbols []string, analyticsRange AnalyticsRange, interval AnalyticsInterval, ohlc OHLCField, windowSize int, calculations []Calculation) analyticsQuery {
+	names := make([]string, 0, len(calculations))
+	for _, calculation := range calculations {
+		names = append(names, string(calculation))
+	}
+
+	return analyticsQuery{
+		Symbols:      strings.ToUpper(strings.Join(symbols, ",")),
+		Range:        analyticsRange.values(),
+		Interval:     strings.ToUpper(string(interval)),
+		OHLC:         strings.ToLower(string(ohlc)),
+		WindowSize:   windowSize,
+		Calculations: strings.Join(names, ","),
+	}
+}
+
+type AnalyticsMetaData struct {
+	Symbols    string    `json:"symbols"`
+	MinDate    time.Time `json:"min_dt"`
+	MaxDate    time.Time `json:"max_dt"`
+	OHLC       string    `json:"ohlc"`
+	Interval   string    `json:"interval"`
+	WindowSize int       `json:"window_size"`
+}
+
+func (a *AnalyticsMetaData) UnmarshalJSON(data []byte) error {
+	var metaData AnalyticsMetaData
+	if err := decodeJSONRecord(data, &metaData); err != nil {
+		return err
+	}
+	*a = metaData
+	return nil
+}
+
+type AnalyticsMatrix struct {
+	Symbols []string
+	Values  [][]float64
+}
+
+func (a AnalyticsMatrix) Get(first string, second string) (float64, bool) {
+	i, j := -1, -1
+	for index, symbol := range a.Symbols {
+		if strings.EqualFold(symbol, first) {
+			i = index
+		}
+		if strings.EqualFold(symbol, second) {
+			j = index
+		}
+	}
+	if i < 0 || j < 0 {
+		return 0, false
+	}
+	return a.Values[i][j], true
+}
+
+type Drawdown struct {
+	MaxDrawdown float64
+	Start       time.Time
+	End         time.Time
+}
+
+type Histogram struct {
+	BinCounts []int64
+	BinEdges  []float64
+}
+
+type AnalyticsPoint struct {
+	Date  time.Time
+	Value float64
+}
+
+type FixedWindowAnalytics struct {
+	MetaData   AnalyticsMetaData
+	Scalars    map[string]map[string]float64
+	Matrices   map[string]AnalyticsMatrix
+	Drawdowns  map[string]Drawdown
+	Histograms map[string]map[string]Histogram
+	Raw        map[string]json.RawMessage
+}
+
+func (f *FixedWindowAnalytics) Scalar(calculation Calculation, symbol string) (float64, bool) {
+	value, ok := f.Scalars[calculation.key()][strings.ToUpper(symbol)]
+	return value, ok
+}
+
+func (f *FixedWindowAnalytics) Matrix(calculation Calculation) (AnalyticsMatrix, bool) {
+	matrix, ok := f.Matrices[calculation.key()]
+	return matrix, ok
+}
+
+func (f *FixedWindowAnalytics) Histogram(calculation Calculation, symbol string) (Histogram, bool) {
+	histogram, ok := f.Histograms[calculation.key()][strings.ToUpper(symbol)]
+	return histogram, ok
+}
+
+type SlidingWindowAnalytics struct {
+	MetaData AnalyticsMetaData
+	Series   map[string]map[string][]AnalyticsPoint
+	Raw      map[string]json.RawMessage
+}
+
+func (s *SlidingWindowAnalytics) SeriesFor(calculation Calculation, key string) []AnalyticsPoint {
+	return s.Series[calculation.key()][strings.ToUpper(key)]
+}
+
+type analyticsResponseJSON struct {
+	MetaData AnalyticsMetaData `json:"meta_data"`
+	Payload  struct {
+		ReturnsCalculations map[string]json.RawMessage `json:"RETURNS_CALCULATIONS"`
+	} `json:"payload"`
+}
+
+func (f *FixedWindowAnalytics) UnmarshalJSON(data []byte) error {
+	var raw analyticsResponseJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	analytics := FixedWindowAnalytics{
+		MetaData:   raw.MetaData,
+		Scalars:    make(map[string]map[string]float64),
+		Matrices:   make(map[string]AnalyticsMatrix),
+		Drawdowns:  make(map[string]Drawdown),
+		Histograms: make(map[string]map[string]Histogram),
+		Raw:        make(map[string]json.RawMessage),
+	}
+
+	for key, value := range raw.Payload.ReturnsCalculations {
+		calculation := Calculation(key)
+		key = calculation.key()
+
+		var err error
+		switch calculation.Name() {
+		case CalculationCorrelation, CalculationCovariance:
+			var matrix AnalyticsMatrix
+			if matrix, err = decodeAnalyticsMatrix(value); err == nil {
+				analytics.Matrices[key] = matrix
+			}
+		case CalculationMaxDrawdown:
+			err = decodeAnalyticsDrawdowns(value, analytics.Drawdowns)
+		case CalculationHistogram:
+			var histograms map[string]Histogram
+			if histograms, err = decodeAnalyticsHistograms(value); err == nil {
+				analytics.Histograms[key] = histograms
+			}
+		default:
+			var scalars map[string]float64
+			if err = json.Unmarshal(value, &scalars); err == nil {
+				analytics.Scalars[key] = upperCaseKeys(scalars)
+			}
+		}
+
+		if err != nil {
+			analytics.Raw[key] = value
+		}
+	}
+
+	*f = analytics
+	return nil
+}
+
+func upperCaseKeys[T any](values map[string]T) map[string]T {
+	result := make(map[string]T, len(values))
+	for key, value := range values {
+		result[strings.ToUpper(key)] = value
+	}
+	return result
+}
+
+func decodeAnalyticsMatrix(data []byte) (AnalyticsMatrix, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return AnalyticsMatrix{}, err
+	}
+
+	var matrix AnalyticsMatrix
+	if err := json.Unmarshal(fields["index"], &matrix.Symbols); err != nil {
+		return AnalyticsMatrix{}, err
+	}
+
+	var rows [][]float64
+	for key, value := range fields {
+		if key == "index" {
+			continue
+		}
+		if err := json.Unmarshal(value, &rows); err != nil {
+			return AnalyticsMatrix{}, err
+		}
+	}
+	if len(rows) != len(matrix.Symbols) {
+		return AnalyticsMatrix{}, fmt.Errorf("matrix has %d rows for %d symbols", len(rows), len(matrix.Symbols))
+	}
+
+	// Alpha Vantage only returns the lower triangle of the symmetric matrix
+	matrix.Values = make([][]float64, len(rows))
+	for i := range rows {
+		matrix.Values[i] = make([]float64, len(rows))
+	}
+	for i, row := range rows {
+		for j, value := range row {
+			if j >= len(rows) {
+				return AnalyticsMatrix{}, fmt.Errorf("matrix row %d has too many values", i)
+			}
+			matrix.Values[i][j] = value
+			matrix.Values[j][i] = value
+		}
+	}
+	return matrix, nil
+}
+
+func decodeAnalyticsDrawdowns(data []byte, drawdowns map[string]Drawdown) error {
+	var raw map[string]struct {
+		MaxDrawdown   float64 `json:"max_drawdown"`
+		DrawdownRange struct {
+			StartDrawdown string `json:"start_drawdown"`
+			EndDrawdown   string `json:"end_drawdown"`
+		} `json:"drawdown_range"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	for symbol, value := range raw {
+		start, err := parseRecordTime(value.DrawdownRange.StartDrawdown, "")
+		if err != nil {
+			return err
+		}
+		end, err := parseRecordTime(value.DrawdownRange.EndDrawdown, "")
+		if err != nil {
+			return err
+		}
+		drawdowns[strings.ToUpper(symbol)] = Drawdown{MaxDrawdown: value.MaxDrawdown, Start: start, End: end}
+	}
+	return nil
+}
+
+func decodeAnalyticsHistograms(data []byte) (map[string]Histogram, error) {
+	var raw map[string]struct {
+		BinCount []int64   `json:"bin_count"`
+		BinEdges []float64 `json:"bin_edges"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+
+	histograms := make(map[string]Histogram, len(raw))
+	for symbol, value := range raw {
+		histograms[strings.ToUpper(symbol)] = Histogram{BinCounts: value.BinCount, BinEdges: value.BinEdges}
+	}
+	return histograms, nil
+}
+
+func (s *SlidingWindowAnalytics) UnmarshalJSON(data []byte) error {
+	var raw analyticsResponseJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	analytics := SlidingWindowAnalytics{
+		MetaData: raw.MetaData,
+		Series:   make(map[string]map[string][]AnalyticsPoint),
+		Raw:      make(map[string]json.RawMessage),
+	}
+
+	for key, value := range raw.Payload.ReturnsCalculations {
+		key = Calculation(key).key()
+
+		series, err := decodeAnalyticsSeries(value)
+		if err != nil {
+			analytics.Raw[key] = value
+			continue
+		}
+		analytics.Series[key] = series
+	}
+
+	*s = analytics
+	return nil
+}
+
+func decodeAnalyticsSeries(data []byte) (map[string][]AnalyticsPoint, error) {
+	// Sliding window results are nested under a single RUNNING_<CALCULATION> key
+	var running map[string]map[string]map[string]float64
+	if err := json.Unmarshal(data, &running); err != nil {
+		return nil, err
+	}
+
+	series := make(map[string][]AnalyticsPoint)
+	for _, values := range running {
+		for key, points := range values {
+			key = strings.ToUpper(key)
+			for date, value := range points {
+				t, err := parseRecordTime(date, "")
+				if err != nil {
+					return nil, fmt.Errorf("invalid date %q: %w", date, err)
+				}
+				series[key] = append(series[key], AnalyticsPoint{Date: t, Value: value})
+			}
+
+			sort.Slice(series[key], func(i, j int) bool {
+				return series[key][i].Date.Before(series[key][j].Date)
+			})
+		}
+	}
+	return series, nil
+}
+
+func (c *Client) GetAnalyticsFixedWindow(ctx context.Context, options *AnalyticsFixedWindowOptions) (*FixedWindowAnalytics, error) {
+	if options == nil || !options.Valid() {
+		return nil, InValidInputError
+	}
+
+	query := newAnalyticsQuery(options.Symbols, options.Range, options.Interval, options.OHLC, 0, options.Calculations)
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%sfunction=ANALYTICS_FIXED_WINDOW&%s", c.BaseURL, c.buildQuery(query)), nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	var res FixedWindowAnalytics
+	if err = c.doJSONRequest(req, &res); err != nil {
+		return nil, fmt.Errorf("failed to get fixed window analytics: %w", err)
+	}
+	return &res, nil
+}
+
+func (c *Client) GetAnalyticsSlidingWindow(ctx context.Context, options *AnalyticsSlidingWindowOptions) (*SlidingWindowAnalytics, error) {
+	if options == nil || !options.Valid() {
+		return nil, InValidInputError
+	}
+
+	query := newAnalyticsQuery(options.Symbols, options.Range, options.Interval, options.OHLC, options.WindowSize, options.Calculations)
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%sfunction=ANALYTICS_SLIDING_WINDOW&%s", c.BaseURL, c.buildQuery(query)), nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	var res SlidingWindowAnalytics
+	if err = c.doJSONRequest(req, &res); err != nil {
+		return nil, fmt.Errorf("failed to get sliding window analytics: %w", err)
+	}
+	return &res, nil
+}
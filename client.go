@@ -7,9 +7,12 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"math"
 	"net/http"
 	"net/url"
 	"reflect"
+	"regexp"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -21,9 +24,10 @@ var (
 )
 
 type Client struct {
-	BaseURL    string
-	apiKey     string
-	HTTPClient *http.Client
+	BaseURL     string
+	apiKey      string
+	HTTPClient  *http.Client
+	Entitlement Entitlement
 }
 
 type statusErrorResponse struct {
@@ -129,23 +133,7 @@ func readCSV(reader *csv.Reader, v interface{}) error {
 		records = append(records, csvRow)
 	}
 
-	switch v := v.(type) {
-	case *[]Listing:
-		for _, record := range records {
-			listing := Listing{
-				Symbol:    record["symbol"],
-				Name:      record["name"],
-				Exchange:  record["exchange"],
-				AssetType: record["assetType"],
-			}
-
-			*v = append(*v, listing)
-		}
-	default:
-		return fmt.Errorf("unsupported type %T for v", v)
-	}
-
-	return nil
+	return decodeRecords(records, "csv", v)
 }
 
 func (c *Client) buildQuery(options interface{}) string {
@@ -188,6 +176,80 @@ func (c *Client) buildQuery(options interface{}) string {
 	return queryParams.Encode()
 }
 
+func parseFloat(value string) (float64, error) {
+	return strconv.ParseFloat(strings.TrimSpace(value), 64)
+}
+
+func parseOptionalFloat(value string) (*float64, error) {
+	if isNullValue(value) {
+		return nil, nil
+	}
+
+	f, err := parseFloat(value)
+	if err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
+
+func parseOptionalInt(value string) (*int64, error) {
+	if isNullValue(value) {
+		return nil, nil
+	}
+
+	i, err := parseInt(value)
+	if err != nil {
+		return nil, err
+	}
+	return &i, nil
+}
+
+func parseInt(value string) (int64, error) {
+	value = strings.TrimSpace(value)
+	i, err := strconv.ParseInt(value, 10, 64)
+	if err == nil || errors.Is(err, strconv.ErrRange) {
+		return i, err
+	}
+
+	// Some endpoints report whole counts with a trailing ".0"
+	f, floatErr := strconv.ParseFloat(value, 64)
+	if floatErr != nil || f != math.Trunc(f) {
+		return 0, err
+	}
+	if f < math.MinInt64 || f >= math.MaxInt64 {
+		return 0, &strconv.NumError{Func: "ParseInt", Num: value, Err: strconv.ErrRange}
+	}
+	return int64(f), nil
+}
+
+var zeroDateRegex = regexp.MustCompile(`^0{4}-0{2}-0{2}([ T]0{2}:0{2}(:0{2})?)?$`)
+
+func isNullValue(value string) bool {
+	value = strings.ToLower(strings.TrimSpace(value))
+	switch value {
+	case "", "none", "null", "-", "n/a":
+		return true
+	default:
+		// Placeholder dates such as "0000-00-00" mean the date is unknown
+		return zeroDateRegex.MatchString(value)
+	}
+}
+
+func parseEasternTimestamp(value string) (time.Time, error) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	value = strings.TrimSpace(value)
+	for _, layout := range []string{"2006-01-02 15:04:05.000", "2006-01-02 15:04:05", "2006-01-02", "20060102T150405", "20060102T1504"} {
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognized timestamp %q", value)
+}
+
 func checkAPIResponseForErrorMessage(content []byte) error {
 	var apiErrRes apiErrorResponse
 	if err := json.Unmarshal(content, &apiErrRes); err != nil {
@@ -2,9 +2,13 @@ package goalphavantage
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"sort"
 	"strings"
+	"time"
 )
 
 type Function string
@@ -13,6 +17,12 @@ type Interval string
 type OutputSize string
 
 type BoolString string
+type Entitlement string
+
+const (
+	EntitlementRealtime Entitlement = "realtime"
+	EntitlementDelayed  Entitlement = "delayed"
+)
 
 func (f Function) Valid() bool {
 	switch strings.ToUpper(string(f)) {
@@ -59,6 +69,34 @@ func (b BoolString) Valid() bool {
 	}
 }
 
+func (e Entitlement) Valid() bool {
+	switch strings.ToLower(string(e)) {
+	case "", "realtime", "delayed":
+		return true
+	default:
+		return false
+	}
+}
+
+func entitlementMarker(text string) Entitlement {
+	text = strings.ToLower(text)
+	switch {
+	case strings.Contains(text, "delayed"):
+		return EntitlementDelayed
+	case strings.Contains(text, "realtime"), strings.Contains(text, "real-time"):
+		return EntitlementRealtime
+	default:
+		return ""
+	}
+}
+
+func (m *MetaData) Entitlement() Entitlement {
+	if m == nil || m.Information == nil {
+		return ""
+	}
+	return entitlementMarker(*m.Information)
+}
+
 func (c CoreStockSharedInputOptions) Valid() bool {
 	if !c.Function.Valid() || c.Symbol == "" {
 		return false
@@ -68,21 +106,22 @@ func (c CoreStockSharedInputOptions) Valid() bool {
 		return false
 	}
 
-	if !c.Datatype.Valid() || !c.Adjusted.Valid() || !c.ExtendedHours.Valid() || !c.OutputSize.Valid() {
+	if !c.Datatype.Valid() || !c.Adjusted.Valid() || !c.ExtendedHours.Valid() || !c.OutputSize.Valid() || !c.Entitlement.Valid() {
 		return false
 	}
 	return true
 }
 
 type CoreStockSharedInputOptions struct {
-	Function      Function   `url:"function"`
-	Symbol        string     `url:"symbol"`
-	Interval      Interval   `url:"interval"`
-	Datatype      DataType   `url:"datatype, omitempty"`
-	Adjusted      BoolString `url:"adjusted, omitempty"`
-	ExtendedHours BoolString `url:"extended_hours, omitempty"`
-	Month         string     `url:"month, omitempty"`
-	OutputSize    OutputSize `url:"outputsize, omitempty"`
+	Function      Function    `url:"function"`
+	Symbol        string      `url:"symbol"`
+	Interval      Interval    `url:"interval"`
+	Datatype      DataType    `url:"datatype, omitempty"`
+	Adjusted      BoolString  `url:"adjusted, omitempty"`
+	ExtendedHours BoolString  `url:"extended_hours, omitempty"`
+	Month         string      `url:"month, omitempty"`
+	OutputSize    OutputSize  `url:"outputsize, omitempty"`
+	Entitlement   Entitlement `url:"entitlement, omitempty"`
 }
 
 type MetaData struct {
@@ -94,28 +133,68 @@ type MetaData struct {
 	TimeZone      *string `json:"6. Time Zone,omitempty"`
 }
 
+func (m *MetaData) UnmarshalJSON(data []byte) error {
+	var fields map[string]*string
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	// The numeric prefix depends on the series, e.g. daily sends "5. Time Zone" and weekly sends "4. Time Zone"
+	var metaData MetaData
+	for key, value := range fields {
+		if _, name, found := strings.Cut(key, ". "); found {
+			key = name
+		}
+
+		switch normalizeRecordKey(key) {
+		case "information":
+			metaData.Information = value
+		case "symbol":
+			metaData.Symbol = value
+		case "lastrefreshed":
+			metaData.LastRefreshed = value
+		case "interval":
+			metaData.Interval = value
+		case "outputsize":
+			metaData.OutputSize = value
+		case "timezone":
+			metaData.TimeZone = value
+		}
+	}
+
+	*m = metaData
+	return nil
+}
+
 type CoreStockData struct {
-	Open                  *string `json:"1. open"`
-	High                  *string `json:"2. high"`
-	Low                   *string `json:"3. low"`
-	Close                 *string `json:"4. close"`
-	Volume                *string `json:"5. volume,omitempty"`
-	AdjustedClose         *string `json:"5. adjusted close,omitempty"`
+	Open                  *string `json:"1. open" csv:"open"`
+	High                  *string `json:"2. high" csv:"high"`
+	Low                   *string `json:"3. low" csv:"low"`
+	Close                 *string `json:"4. close" csv:"close"`
+	Volume                *string `json:"5. volume,omitempty" csv:"volume"`
+	AdjustedClose         *string `json:"5. adjusted close,omitempty" csv:"adjusted_close"`
 	VolumeForAdjustedCall *string `json:"6. volume,omitempty"`
-	DividendAmount        *string `json:"7. dividend amount,omitempty"`
+	DividendAmount        *string `json:"7. dividend amount,omitempty" csv:"dividend_amount"`
+	SplitCoefficient      *string `json:"8. split coefficient,omitempty" csv:"split_coefficient"`
+}
+
+type coreStockCSVRow struct {
+	Timestamp string `csv:"timestamp"`
+	CoreStockData
 }
 
 type GlobalQuote struct {
-	Symbol           *string `json:"01. symbol"`
-	Open             *string `json:"02. open"`
-	High             *string `json:"03. high"`
-	Low              *string `json:"04. low"`
-	Price            *string `json:"05. price"`
-	Volume           *string `json:"06. volume"`
-	LatestTradingDay *string `json:"07. latest trading day"`
-	PreviousClose    *string `json:"08. previous close"`
-	Change           *string `json:"09. change"`
-	ChangePercent    *string `json:"10. change percent"`
+	Symbol           *string     `json:"01. symbol" csv:"symbol"`
+	Open             *string     `json:"02. open" csv:"open"`
+	High             *string     `json:"03. high" csv:"high"`
+	Low              *string     `json:"04. low" csv:"low"`
+	Price            *string     `json:"05. price" csv:"price"`
+	Volume           *string     `json:"06. volume" csv:"volume"`
+	LatestTradingDay *string     `json:"07. latest trading day" csv:"latestDay"`
+	PreviousClose    *string     `json:"08. previous close" csv:"previousClose"`
+	Change           *string     `json:"09. change" csv:"change"`
+	ChangePercent    *string     `json:"10. change percent" csv:"changePercent"`
+	Entitlement      Entitlement `json:"-"`
 }
 
 type CoreStockResponse struct {
@@ -131,14 +210,53 @@ type CoreStockResponse struct {
 	DailyTimeSeries           map[string]*CoreStockData `json:"Time Series (Daily),omitempty"`
 	WeeklyTimeSeries          map[string]*CoreStockData `json:"Weekly Time Series,omitempty"`
 	WeeklyAdjustedTimeSeries  map[string]*CoreStockData `json:"Weekly Adjusted Time Series,omitempty"`
+	Entitlement               Entitlement               `json:"-"`
 }
 
-func (c *Client) GetTimeSeriesStockData(ctx context.Context, options *CoreStockSharedInputOptions) (*CoreStockResponse, error) {
-	if !options.Valid() {
+type SymbolMatch struct {
+	Symbol      string  `json:"1. symbol" csv:"symbol"`
+	Name        string  `json:"2. name" csv:"name"`
+	Type        string  `json:"3. type" csv:"type"`
+	Region      string  `json:"4. region" csv:"region"`
+	MarketOpen  string  `json:"5. marketOpen" csv:"marketOpen"`
+	MarketClose string  `json:"6. marketClose" csv:"marketClose"`
+	TimeZone    string  `json:"7. timezone" csv:"timezone"`
+	Currency    string  `json:"8. currency" csv:"currency"`
+	MatchScore  float64 `json:"9. matchScore" csv:"matchScore"`
+}
+
+func (s *SymbolMatch) UnmarshalJSON(data []byte) error {
+	var match SymbolMatch
+	if err := decodeJSONRecord(data, &match); err != nil {
+		return err
+	}
+	*s = match
+	return nil
+}
+
+type SymbolSearchResponse struct {
+	BestMatches []SymbolMatch `json:"bestMatches"`
+}
+
+func (s SymbolSearchOptions) Valid() bool {
+	return strings.TrimSpace(s.Keywords) != "" && s.Datatype.Valid()
+}
+
+type SymbolSearchOptions struct {
+	Keywords string   `url:"keywords"`
+	Datatype DataType `url:"datatype, omitempty"`
+}
+
+func (c *Client) SearchSymbols(ctx context.Context, keywords string) (*SymbolSearchResponse, error) {
+	return c.SearchSymbolsWithOptions(ctx, &SymbolSearchOptions{Keywords: keywords})
+}
+
+func (c *Client) SearchSymbolsWithOptions(ctx context.Context, options *SymbolSearchOptions) (*SymbolSearchResponse, error) {
+	if options == nil || !options.Valid() {
 		return nil, InValidInputError
 	}
 
-	apiURL := fmt.Sprintf("%s%s", c.BaseURL, c.buildQuery(options))
+	apiURL := fmt.Sprintf("%sfunction=SYMBOL_SEARCH&%s", c.BaseURL, c.buildQuery(options))
 
 	req, err := http.NewRequest(http.MethodGet, apiURL, nil)
 	if err != nil {
@@ -147,16 +265,626 @@ func (c *Client) GetTimeSeriesStockData(ctx context.Context, options *CoreStockS
 
 	req = req.WithContext(ctx)
 
-	var res CoreStockResponse
+	var res SymbolSearchResponse
 	if strings.ToLower(string(options.Datatype)) == "csv" {
-		if err := c.doCSVRequest(req, &res); err != nil {
-			return nil, fmt.Errorf("failed to get time sereis stock data: %w", err)
+		if err := c.doCSVRequest(req, &res.BestMatches); err != nil {
+			return nil, fmt.Errorf("failed to search symbols: %w", err)
 		}
 	} else {
 		if err := c.doJSONRequest(req, &res); err != nil {
+			return nil, fmt.Errorf("failed to search symbols: %w", err)
+		}
+	}
+
+	return &res, nil
+}
+
+type MarketState string
+
+const (
+	MarketStateOpen   MarketState = "open"
+	MarketStateClosed MarketState = "closed"
+)
+
+type ClockTime struct {
+	Hour   int
+	Minute int
+}
+
+func parseClockTime(value string) (ClockTime, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(value))
+	if err != nil {
+		return ClockTime{}, err
+	}
+	return ClockTime{Hour: t.Hour(), Minute: t.Minute()}, nil
+}
+
+func (c ClockTime) String() string {
+	return fmt.Sprintf("%02d:%02d", c.Hour, c.Minute)
+}
+
+var marketRegionTimeZones = map[string]string{
+	"united states":  "America/New_York",
+	"canada":         "America/Toronto",
+	"united kingdom": "Europe/London",
+	"germany":        "Europe/Berlin",
+	"france":         "Europe/Paris",
+	"spain":          "Europe/Madrid",
+	"portugal":       "Europe/Lisbon",
+	"japan":          "Asia/Tokyo",
+	"india":          "Asia/Kolkata",
+	"mainland china": "Asia/Shanghai",
+	"hong kong":      "Asia/Hong_Kong",
+	"brazil":         "America/Sao_Paulo",
+	"mexico":         "America/Mexico_City",
+	"south africa":   "Africa/Johannesburg",
+	"global":         "UTC",
+}
+
+type Market struct {
+	MarketType       string      `json:"market_type"`
+	Region           string      `json:"region"`
+	PrimaryExchanges []string    `json:"primary_exchanges"`
+	LocalOpen        ClockTime   `json:"local_open"`
+	LocalClose       ClockTime   `json:"local_close"`
+	CurrentStatus    MarketState `json:"current_status"`
+	Notes            string      `json:"notes"`
+}
+
+type marketJSON struct {
+	MarketType       string `json:"market_type"`
+	Region           string `json:"region"`
+	PrimaryExchanges string `json:"primary_exchanges"`
+	LocalOpen        string `json:"local_open"`
+	LocalClose       string `json:"local_close"`
+	CurrentStatus    string `json:"current_status"`
+	Notes            string `json:"notes"`
+}
+
+func (m *Market) UnmarshalJSON(data []byte) error {
+	var raw marketJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	localOpen, err := parseClockTime(raw.LocalOpen)
+	if err != nil {
+		return fmt.Errorf("invalid local open for %s %s: %w", raw.Region, raw.MarketType, err)
+	}
+
+	localClose, err := parseClockTime(raw.LocalClose)
+	if err != nil {
+		return fmt.Errorf("invalid local close for %s %s: %w", raw.Region, raw.MarketType, err)
+	}
+
+	var exchanges []string
+	for _, exchange := range strings.Split(raw.PrimaryExchanges, ",") {
+		if exchange = strings.TrimSpace(exchange); exchange != "" {
+			exchanges = append(exchanges, exchange)
+		}
+	}
+
+	*m = Market{
+		MarketType:       raw.MarketType,
+		Region:           raw.Region,
+		PrimaryExchanges: exchanges,
+		LocalOpen:        localOpen,
+		LocalClose:       localClose,
+		CurrentStatus:    MarketState(strings.ToLower(raw.CurrentStatus)),
+		Notes:            raw.Notes,
+	}
+	return nil
+}
+
+func (m Market) Location() (*time.Location, error) {
+	name, ok := marketRegionTimeZones[strings.ToLower(m.Region)]
+	if !ok {
+		return nil, fmt.Errorf("unknown time zone for market region %q", m.Region)
+	}
+	return time.LoadLocation(name)
+}
+
+func (m Market) tradesOn(day time.Weekday) bool {
+	if strings.EqualFold(m.MarketType, "Cryptocurrency") {
+		return true
+	}
+	return day != time.Saturday && day != time.Sunday
+}
+
+func (m Market) IsOpenAt(t time.Time) (bool, error) {
+	loc, err := m.Location()
+	if err != nil {
+		return false, err
+	}
+
+	local := t.In(loc)
+	if !m.tradesOn(local.Weekday()) {
+		return false, nil
+	}
+
+	minute := local.Hour()*60 + local.Minute()
+	return minute >= m.LocalOpen.Hour*60+m.LocalOpen.Minute && minute < m.LocalClose.Hour*60+m.LocalClose.Minute, nil
+}
+
+func (m Market) NextOpen(now time.Time) (time.Time, error) {
+	loc, err := m.Location()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	local := now.In(loc)
+	for day := 0; day <= 7; day++ {
+		candidate := time.Date(local.Year(), local.Month(), local.Day()+day, m.LocalOpen.Hour, m.LocalOpen.Minute, 0, 0, loc)
+		if candidate.After(now) && m.tradesOn(candidate.Weekday()) {
+			return candidate, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("no upcoming session found for %s %s", m.Region, m.MarketType)
+}
+
+type MarketStatusResponse struct {
+	Endpoint string   `json:"endpoint"`
+	Markets  []Market `json:"markets"`
+}
+
+func (m *MarketStatusResponse) MarketsInRegion(region string) []Market {
+	var markets []Market
+	for _, market := range m.Markets {
+		if strings.EqualFold(market.Region, region) {
+			markets = append(markets, market)
+		}
+	}
+	return markets
+}
+
+func (m *MarketStatusResponse) IsOpen(region string) (bool, error) {
+	return m.IsOpenAt(region, time.Now())
+}
+
+func (m *MarketStatusResponse) IsOpenAt(region string, t time.Time) (bool, error) {
+	markets := m.MarketsInRegion(region)
+	if len(markets) == 0 {
+		return false, fmt.Errorf("no markets found for region %q", region)
+	}
+
+	for _, market := range markets {
+		open, err := market.IsOpenAt(t)
+		if err != nil {
+			return false, err
+		}
+		if open {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *MarketStatusResponse) NextOpen(region string, now time.Time) (time.Time, error) {
+	markets := m.MarketsInRegion(region)
+	if len(markets) == 0 {
+		return time.Time{}, fmt.Errorf("no markets found for region %q", region)
+	}
+
+	var next time.Time
+	for _, market := range markets {
+		open, err := market.NextOpen(now)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if next.IsZero() || open.Before(next) {
+			next = open
+		}
+	}
+	return next, nil
+}
+
+func (c *Client) GetMarketStatus(ctx context.Context) (*MarketStatusResponse, error) {
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%sfunction=MARKET_STATUS", c.BaseURL), nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	var res MarketStatusResponse
+	if err = c.doJSONRequest(req, &res); err != nil {
+		return nil, fmt.Errorf("failed to get market status: %w", err)
+	}
+	return &res, nil
+}
+
+const bulkQuoteSymbolLimit = 100
+
+var QuoteNotFoundError = errors.New("no quote returned for symbol")
+
+type Quote struct {
+	Symbol                     string
+	Timestamp                  time.Time
+	Open                       float64
+	High                       float64
+	Low                        float64
+	Close                      float64
+	Volume                     int64
+	PreviousClose              float64
+	Change                     float64
+	ChangePercent              float64
+	ExtendedHoursQuote         *float64
+	ExtendedHoursChange        *float64
+	ExtendedHoursChangePercent *float64
+}
+
+type quoteJSON struct {
+	Symbol                     string `json:"symbol"`
+	Timestamp                  string `json:"timestamp"`
+	Open                       string `json:"open"`
+	High                       string `json:"high"`
+	Low                        string `json:"low"`
+	Close                      string `json:"close"`
+	Volume                     string `json:"volume"`
+	PreviousClose              string `json:"previous_close"`
+	Change                     string `json:"change"`
+	ChangePercent              string `json:"change_percent"`
+	ExtendedHoursQuote         string `json:"extended_hours_quote"`
+	ExtendedHoursChange        string `json:"extended_hours_change"`
+	ExtendedHoursChangePercent string `json:"extended_hours_change_percent"`
+}
+
+func (q quoteJSON) quote() (Quote, error) {
+	var err error
+	quote := Quote{Symbol: strings.ToUpper(q.Symbol)}
+
+	if q.Timestamp != "" {
+		if quote.Timestamp, err = parseEasternTimestamp(q.Timestamp); err != nil {
+			return Quote{}, fmt.Errorf("invalid timestamp for %s: %w", q.Symbol, err)
+		}
+	}
+
+	prices := []struct {
+		name   string
+		value  string
+		target *float64
+	}{
+		{"open", q.Open, &quote.Open},
+		{"high", q.High, &quote.High},
+		{"low", q.Low, &quote.Low},
+		{"close", q.Close, &quote.Close},
+		{"previous close", q.PreviousClose, &quote.PreviousClose},
+		{"change", q.Change, &quote.Change},
+		{"change percent", strings.TrimSuffix(q.ChangePercent, "%"), &quote.ChangePercent},
+	}
+	for _, price := range prices {
+		if *price.target, err = parseFloat(price.value); err != nil {
+			return Quote{}, fmt.Errorf("invalid %s for %s: %w", price.name, q.Symbol, err)
+		}
+	}
+
+	if quote.Volume, err = parseInt(q.Volume); err != nil {
+		return Quote{}, fmt.Errorf("invalid volume for %s: %w", q.Symbol, err)
+	}
+
+	if quote.ExtendedHoursQuote, err = parseOptionalFloat(q.ExtendedHoursQuote); err != nil {
+		return Quote{}, fmt.Errorf("invalid extended hours quote for %s: %w", q.Symbol, err)
+	}
+	if quote.ExtendedHoursChange, err = parseOptionalFloat(q.ExtendedHoursChange); err != nil {
+		return Quote{}, fmt.Errorf("invalid extended hours change for %s: %w", q.Symbol, err)
+	}
+	if quote.ExtendedHoursChangePercent, err = parseOptionalFloat(strings.TrimSuffix(q.ExtendedHoursChangePercent, "%")); err != nil {
+		return Quote{}, fmt.Errorf("invalid extended hours change percent for %s: %w", q.Symbol, err)
+	}
+
+	return quote, nil
+}
+
+func (q *Quote) UnmarshalJSON(data []byte) error {
+	var raw quoteJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	quote, err := raw.quote()
+	if err != nil {
+		return err
+	}
+
+	*q = quote
+	return nil
+}
+
+type bulkQuotesJSON struct {
+	Endpoint string      `json:"endpoint"`
+	Message  string      `json:"message"`
+	Data     []quoteJSON `json:"data"`
+}
+
+type bulkQuotesOptions struct {
+	Symbol      string      `url:"symbol"`
+	Entitlement Entitlement `url:"entitlement, omitempty"`
+}
+
+type BulkQuotesResponse struct {
+	Quotes      map[string]Quote
+	Misses      map[string]error
+	Entitlement Entitlement
+}
+
+func (c *Client) GetBulkQuotes(ctx context.Context, symbols []string) (*BulkQuotesResponse, error) {
+	if !c.Entitlement.Valid() {
+		return nil, InValidInputError
+	}
+
+	var uniqueSymbols []string
+	seen := make(map[string]bool)
+	for _, symbol := range symbols {
+		symbol = strings.ToUpper(strings.TrimSpace(symbol))
+		if symbol == "" {
+			return nil, InValidInputError
+		}
+		if !seen[symbol] {
+			seen[symbol] = true
+			uniqueSymbols = append(uniqueSymbols, symbol)
+		}
+	}
+
+	if len(uniqueSymbols) == 0 {
+		return nil, InValidInputError
+	}
+
+	res := BulkQuotesResponse{
+		Quotes: make(map[string]Quote),
+		Misses: make(map[string]error),
+	}
+
+	for start := 0; start < len(uniqueSymbols); start += bulkQuoteSymbolLimit {
+		end := start + bulkQuoteSymbolLimit
+		if end > len(uniqueSymbols) {
+			end = len(uniqueSymbols)
+		}
+		chunk := uniqueSymbols[start:end]
+
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		// Only symbols missing from a successful response count as misses; API and transport errors abort the call
+		data, entitlement, err := c.getBulkQuotesChunk(ctx, chunk)
+		if err != nil {
+			return nil, err
+		}
+
+		// Chunks that disagree leave the entitlement unknown
+		if start == 0 {
+			res.Entitlement = entitlement
+		} else if res.Entitlement != entitlement {
+			res.Entitlement = ""
+		}
+
+		for _, raw := range data {
+			quote, err := raw.quote()
+			if err != nil {
+				res.Misses[strings.ToUpper(raw.Symbol)] = err
+				continue
+			}
+			res.Quotes[quote.Symbol] = quote
+		}
+
+		for _, symbol := range chunk {
+			if _, ok := res.Quotes[symbol]; !ok && res.Misses[symbol] == nil {
+				res.Misses[symbol] = QuoteNotFoundError
+			}
+		}
+	}
+
+	return &res, nil
+}
+
+func (c *Client) getBulkQuotesChunk(ctx context.Context, symbols []string) ([]quoteJSON, Entitlement, error) {
+	options := bulkQuotesOptions{Symbol: strings.Join(symbols, ","), Entitlement: c.Entitlement}
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%sfunction=REALTIME_BULK_QUOTES&%s", c.BaseURL, c.buildQuery(options)), nil)
+	if err != nil {
+		return nil, "", err
+	}
+	req = req.WithContext(ctx)
+	var res bulkQuotesJSON
+	if err = c.doJSONRequest(req, &res); err != nil {
+		return nil, "", fmt.Errorf("failed to get bulk quotes: %w", err)
+	}
+	// The endpoint is always named "Realtime Bulk Quotes", so only the message can mark the entitlement
+	return res.Data, entitlementMarker(res.Message), nil
+}
+
+type Bar struct {
+	Time             time.Time
+	Open             float64
+	High             float64
+	Low              float64
+	Close            float64
+	Volume           int64
+	AdjustedClose    *float64
+	DividendAmount   *float64
+	SplitCoefficient *float64
+}
+
+func (r *CoreStockResponse) Bars() ([]Bar, error) {
+	var timeZone *string
+	if r.MetaData != nil {
+		timeZone = r.MetaData.TimeZone
+	}
+
+	for _, series := range []map[string]*CoreStockData{
+		r.OneMinTimeSeries, r.FiveMinTimeSeries, r.FifteenMinTimeSeries, r.ThirtyMinTimeSeries, r.HourTimeSeries,
+		r.DailyTimeSeries, r.WeeklyTimeSeries, r.WeeklyAdjustedTimeSeries, r.MonthlyTimeSeries, r.MonthlyAdjustedTimeSeries,
+	} {
+		if series != nil {
+			return seriesBars(series, timeZone)
+		}
+	}
+	return nil, nil
+}
+
+func seriesBars(series map[string]*CoreStockData, timeZone *string) ([]Bar, error) {
+	loc, err := seriesLocation(timeZone)
+	if err != nil {
+		return nil, err
+	}
+
+	bars := make([]Bar, 0, len(series))
+	for timestamp, data := range series {
+		if data == nil {
+			continue
+		}
+
+		bar, err := data.bar(timestamp, loc)
+		if err != nil {
+			return nil, err
+		}
+		bars = append(bars, bar)
+	}
+
+	sort.Slice(bars, func(i, j int) bool {
+		return bars[i].Time.Before(bars[j].Time)
+	})
+	return bars, nil
+}
+
+func seriesLocation(timeZone *string) (*time.Location, error) {
+	name := "US/Eastern"
+	if timeZone != nil && *timeZone != "" {
+		name = *timeZone
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("invalid time zone %q: %w", name, err)
+	}
+	return loc, nil
+}
+
+func (d *CoreStockData) bar(timestamp string, loc *time.Location) (Bar, error) {
+	var bar Bar
+	var err error
+
+	for _, layout := range []string{"2006-01-02 15:04:05", "2006-01-02"} {
+		if bar.Time, err = time.ParseInLocation(layout, timestamp, loc); err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return Bar{}, fmt.Errorf("invalid timestamp %q: %w", timestamp, err)
+	}
+
+	prices := []struct {
+		name   string
+		value  *string
+		target *float64
+	}{
+		{"open", d.Open, &bar.Open},
+		{"high", d.High, &bar.High},
+		{"low", d.Low, &bar.Low},
+		{"close", d.Close, &bar.Close},
+	}
+	for _, price := range prices {
+		if price.value == nil {
+			return Bar{}, fmt.Errorf("missing %s at %s", price.name, timestamp)
+		}
+		if *price.target, err = parseFloat(*price.value); err != nil {
+			return Bar{}, fmt.Errorf("invalid %s at %s: %w", price.name, timestamp, err)
+		}
+	}
+
+	volume := d.Volume
+	if volume == nil {
+		volume = d.VolumeForAdjustedCall
+	}
+	if volume != nil {
+		if bar.Volume, err = parseInt(*volume); err != nil {
+			return Bar{}, fmt.Errorf("invalid volume at %s: %w", timestamp, err)
+		}
+	}
+
+	optionals := []struct {
+		name   string
+		value  *string
+		target **float64
+	}{
+		{"adjusted close", d.AdjustedClose, &bar.AdjustedClose},
+		{"dividend amount", d.DividendAmount, &bar.DividendAmount},
+		{"split coefficient", d.SplitCoefficient, &bar.SplitCoefficient},
+	}
+	for _, optional := range optionals {
+		if optional.value == nil {
+			continue
+		}
+		if *optional.target, err = parseOptionalFloat(*optional.value); err != nil {
+			return Bar{}, fmt.Errorf("invalid %s at %s: %w", optional.name, timestamp, err)
+		}
+	}
+
+	return bar, nil
+}
+
+func (c *Client) GetTimeSeriesStockData(ctx context.Context, options *CoreStockSharedInputOptions) (*CoreStockResponse, error) {
+	if !options.Valid() || !c.Entitlement.Valid() {
+		return nil, InValidInputError
+	}
+
+	// The function is passed separately so the shim shares the dedicated methods' request path
+	query := *options
+	query.Function = ""
+
+	var res CoreStockResponse
+	if strings.ToUpper(string(options.Function)) == "GLOBAL_QUOTE" {
+		quote, err := c.getQuote(ctx, query, options.Datatype, options.Entitlement)
+		if err != nil {
 			return nil, fmt.Errorf("failed to get time sereis stock data: %w", err)
 		}
+		res.LatestQuote = quote
+		if quote != nil {
+			res.Entitlement = quote.Entitlement
+		}
+		return &res, nil
+	}
+
+	function := Function(strings.ToUpper(string(options.Function)))
+	series, err := c.getTimeSeries(ctx, function, query, options.Datatype, options.Entitlement)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get time sereis stock data: %w", err)
 	}
 
+	res.MetaData = series.MetaData
+	res.Entitlement = series.Entitlement
+	if err = res.setTimeSeries(options.Function, options.Interval, series.TimeSeries); err != nil {
+		return nil, fmt.Errorf("failed to get time sereis stock data: %w", err)
+	}
 	return &res, nil
 }
+
+func (r *CoreStockResponse) setTimeSeries(function Function, interval Interval, series map[string]*CoreStockData) error {
+	switch strings.ToUpper(string(function)) {
+	case "TIME_SERIES_INTRADAY":
+		switch strings.ToLower(string(interval)) {
+		case "1min":
+			r.OneMinTimeSeries = series
+		case "5min":
+			r.FiveMinTimeSeries = series
+		case "15min":
+			r.FifteenMinTimeSeries = series
+		case "30min":
+			r.ThirtyMinTimeSeries = series
+		case "60min":
+			r.HourTimeSeries = series
+		default:
+			return fmt.Errorf("unsupported interval %s", interval)
+		}
+	case "TIME_SERIES_DAILY", "TIME_SERIES_DAILY_ADJUSTED":
+		r.DailyTimeSeries = series
+	case "TIME_SERIES_WEEKLY":
+		r.WeeklyTimeSeries = series
+	case "TIME_SERIES_WEEKLY_ADJUSTED":
+		r.WeeklyAdjustedTimeSeries = series
+	case "TIME_SERIES_MONTHLY":
+		r.MonthlyTimeSeries = series
+	case "TIME_SERIES_MONTHLY_ADJUSTED":
+		r.MonthlyAdjustedTimeSeries = series
+	default:
+		return fmt.Errorf("unsupported function %s", function)
+	}
+	return nil
+}
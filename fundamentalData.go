@@ -2,14 +2,21 @@ package goalphavantage
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"math"
+	"math/big"
 	"net/http"
+	"net/url"
+	"reflect"
 	"regexp"
+	"sort"
 	"strings"
 	"time"
 )
 
 type State string
+type Horizon string
 
 func (s State) Valid() bool {
 	switch strings.ToLower(string(s)) {
@@ -46,16 +53,35 @@ func (l ListingStatusOptions) Valid() bool {
 	return true
 }
 
+func (h Horizon) Valid() bool {
+	switch strings.ToLower(string(h)) {
+	case "", "3month", "6month", "12month":
+		return true
+	default:
+		return false
+	}
+}
+
 type ListingStatusOptions struct {
 	Date  string `url:"date"`
 	State State  `url:"state"`
 }
 
 type Listing struct {
-	Symbol    string `json:"symbol"`
-	Name      string `json:"name"`
-	Exchange  string `json:"exchange"`
-	AssetType string `json:"assetType"`
+	Symbol        string     `json:"symbol" csv:"symbol"`
+	Name          string     `json:"name" csv:"name"`
+	Exchange      string     `json:"exchange" csv:"exchange"`
+	AssetType     string     `json:"assetType" csv:"assetType"`
+	IPODate       time.Time  `json:"ipoDate" csv:"ipoDate"`
+	DelistingDate *time.Time `json:"delistingDate" csv:"delistingDate"`
+	Status        string     `json:"status" csv:"status"`
+}
+
+func (l Listing) ActiveOn(date time.Time) bool {
+	if !l.IPODate.IsZero() && date.Before(l.IPODate) {
+		return false
+	}
+	return l.DelistingDate == nil || date.Before(*l.DelistingDate)
 }
 
 func (c *Client) GetListingStatus(ctx context.Context, options *ListingStatusOptions) (*[]Listing, error) {
@@ -73,3 +99,1107 @@ func (c *Client) GetListingStatus(ctx context.Context, options *ListingStatusOpt
 	}
 	return &res, nil
 }
+
+type CompanyOverview struct {
+	Symbol                     string     `json:"Symbol"`
+	AssetType                  string     `json:"AssetType"`
+	Name                       string     `json:"Name"`
+	Description                string     `json:"Description"`
+	CIK                        string     `json:"CIK"`
+	Exchange                   string     `json:"Exchange"`
+	Currency                   string     `json:"Currency"`
+	Country                    string     `json:"Country"`
+	Sector                     string     `json:"Sector"`
+	Industry                   string     `json:"Industry"`
+	Address                    string     `json:"Address"`
+	OfficialSite               string     `json:"OfficialSite"`
+	FiscalYearEnd              string     `json:"FiscalYearEnd"`
+	LatestQuarter              *time.Time `json:"LatestQuarter"`
+	MarketCapitalization       *int64     `json:"MarketCapitalization"`
+	EBITDA                     *int64     `json:"EBITDA"`
+	PERatio                    *float64   `json:"PERatio"`
+	PEGRatio                   *float64   `json:"PEGRatio"`
+	BookValue                  *float64   `json:"BookValue"`
+	DividendPerShare           *float64   `json:"DividendPerShare"`
+	DividendYield              *float64   `json:"DividendYield"`
+	EPS                        *float64   `json:"EPS"`
+	RevenuePerShareTTM         *float64   `json:"RevenuePerShareTTM"`
+	ProfitMargin               *float64   `json:"ProfitMargin"`
+	OperatingMarginTTM         *float64   `json:"OperatingMarginTTM"`
+	ReturnOnAssetsTTM          *float64   `json:"ReturnOnAssetsTTM"`
+	ReturnOnEquityTTM          *float64   `json:"ReturnOnEquityTTM"`
+	RevenueTTM                 *int64     `json:"RevenueTTM"`
+	GrossProfitTTM             *int64     `json:"GrossProfitTTM"`
+	DilutedEPSTTM              *float64   `json:"DilutedEPSTTM"`
+	QuarterlyEarningsGrowthYOY *float64   `json:"QuarterlyEarningsGrowthYOY"`
+	QuarterlyRevenueGrowthYOY  *float64   `json:"QuarterlyRevenueGrowthYOY"`
+	AnalystTargetPrice         *float64   `json:"AnalystTargetPrice"`
+	AnalystRatingStrongBuy     *int64     `json:"AnalystRatingStrongBuy"`
+	AnalystRatingBuy           *int64     `json:"AnalystRatingBuy"`
+	AnalystRatingHold          *int64     `json:"AnalystRatingHold"`
+	AnalystRatingSell          *int64     `json:"AnalystRatingSell"`
+	AnalystRatingStrongSell    *int64     `json:"AnalystRatingStrongSell"`
+	TrailingPE                 *float64   `json:"TrailingPE"`
+	ForwardPE                  *float64   `json:"ForwardPE"`
+	PriceToSalesRatioTTM       *float64   `json:"PriceToSalesRatioTTM"`
+	PriceToBookRatio           *float64   `json:"PriceToBookRatio"`
+	EVToRevenue                *float64   `json:"EVToRevenue"`
+	EVToEBITDA                 *float64   `json:"EVToEBITDA"`
+	Beta                       *float64   `json:"Beta"`
+	FiftyTwoWeekHigh           *float64   `json:"52WeekHigh"`
+	FiftyTwoWeekLow            *float64   `json:"52WeekLow"`
+	FiftyDayMovingAverage      *float64   `json:"50DayMovingAverage"`
+	TwoHundredDayMovingAverage *float64   `json:"200DayMovingAverage"`
+	SharesOutstanding          *int64     `json:"SharesOutstanding"`
+	PercentInsiders            *float64   `json:"PercentInsiders"`
+	PercentInstitutions        *float64   `json:"PercentInstitutions"`
+	DividendDate               *time.Time `json:"DividendDate"`
+	ExDividendDate             *time.Time `json:"ExDividendDate"`
+}
+
+func (o *CompanyOverview) UnmarshalJSON(data []byte) error {
+	var overview CompanyOverview
+	if err := decodeJSONRecord(data, &overview); err != nil {
+		return err
+	}
+	*o = overview
+	return nil
+}
+
+func (c *Client) GetCompanyOverview(ctx context.Context, symbol string) (*CompanyOverview, error) {
+	if strings.TrimSpace(symbol) == "" {
+		return nil, InValidInputError
+	}
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%sfunction=OVERVIEW&symbol=%s", c.BaseURL, url.QueryEscape(symbol)), nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	var res CompanyOverview
+	if err = c.doJSONRequest(req, &res); err != nil {
+		return nil, fmt.Errorf("failed to get company overview: %w", err)
+	}
+	return &res, nil
+}
+
+type ETFSectorWeight struct {
+	Sector string  `json:"sector"`
+	Weight float64 `json:"weight"`
+}
+
+func (e *ETFSectorWeight) UnmarshalJSON(data []byte) error {
+	var sector ETFSectorWeight
+	if err := decodeJSONRecord(data, &sector); err != nil {
+		return err
+	}
+	*e = sector
+	return nil
+}
+
+type ETFHolding struct {
+	Symbol      string  `json:"symbol"`
+	Description string  `json:"description"`
+	Weight      float64 `json:"weight"`
+}
+
+func (e *ETFHolding) UnmarshalJSON(data []byte) error {
+	var holding ETFHolding
+	if err := decodeJSONRecord(data, &holding); err != nil {
+		return err
+	}
+	*e = holding
+	return nil
+}
+
+type ETFProfile struct {
+	NetAssets         *int64            `json:"net_assets"`
+	NetExpenseRatio   *float64          `json:"net_expense_ratio"`
+	PortfolioTurnover *float64          `json:"portfolio_turnover"`
+	DividendYield     *float64          `json:"dividend_yield"`
+	InceptionDate     *time.Time        `json:"inception_date"`
+	Leveraged         bool              `json:"leveraged"`
+	Sectors           []ETFSectorWeight `json:"sectors"`
+	Holdings          []ETFHolding      `json:"holdings"`
+}
+
+func (e *ETFProfile) UnmarshalJSON(data []byte) error {
+	var profile ETFProfile
+	if err := decodeJSONRecord(data, &profile); err != nil {
+		return err
+	}
+	*e = profile
+	return nil
+}
+
+type Exposure struct {
+	Holdings map[string]float64
+	Sectors  map[string]float64
+}
+
+func LookThroughExposure(positions map[string]float64, profiles map[string]*ETFProfile) (*Exposure, error) {
+	exposure := Exposure{
+		Holdings: make(map[string]float64),
+		Sectors:  make(map[string]float64),
+	}
+
+	for symbol, positionWeight := range positions {
+		profile, ok := profiles[symbol]
+		if !ok || profile == nil {
+			return nil, fmt.Errorf("missing ETF profile for %s", symbol)
+		}
+
+		for _, holding := range profile.Holdings {
+			key := holding.Symbol
+			if isNullValue(key) || strings.EqualFold(key, "n/a") {
+				key = holding.Description
+			}
+			exposure.Holdings[key] += positionWeight * holding.Weight
+		}
+
+		for _, sector := range profile.Sectors {
+			exposure.Sectors[sector.Sector] += positionWeight * sector.Weight
+		}
+	}
+
+	return &exposure, nil
+}
+
+func (c *Client) GetETFProfile(ctx context.Context, symbol string) (*ETFProfile, error) {
+	if strings.TrimSpace(symbol) == "" {
+		return nil, InValidInputError
+	}
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%sfunction=ETF_PROFILE&symbol=%s", c.BaseURL, url.QueryEscape(symbol)), nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	var res ETFProfile
+	if err = c.doJSONRequest(req, &res); err != nil {
+		return nil, fmt.Errorf("failed to get ETF profile: %w", err)
+	}
+	return &res, nil
+}
+
+type FinancialReport struct {
+	FiscalDateEnding time.Time `json:"fiscalDateEnding" layout:"2006-01-02"`
+	ReportedCurrency string    `json:"reportedCurrency"`
+	// Line items the statement has no typed field for, keyed as reported
+	Extra map[string]*int64 `json:"-"`
+}
+
+func (f FinancialReport) financialReport() FinancialReport {
+	return f
+}
+
+type statementReport interface {
+	financialReport() FinancialReport
+}
+
+type IncomeStatementReport struct {
+	FinancialReport
+	GrossProfit                       *int64 `json:"grossProfit"`
+	TotalRevenue                      *int64 `json:"totalRevenue"`
+	CostOfRevenue                     *int64 `json:"costOfRevenue"`
+	CostOfGoodsAndServicesSold        *int64 `json:"costofGoodsAndServicesSold"`
+	OperatingIncome                   *int64 `json:"operatingIncome"`
+	SellingGeneralAndAdministrative   *int64 `json:"sellingGeneralAndAdministrative"`
+	ResearchAndDevelopment            *int64 `json:"researchAndDevelopment"`
+	OperatingExpenses                 *int64 `json:"operatingExpenses"`
+	InvestmentIncomeNet               *int64 `json:"investmentIncomeNet"`
+	NetInterestIncome                 *int64 `json:"netInterestIncome"`
+	InterestIncome                    *int64 `json:"interestIncome"`
+	InterestExpense                   *int64 `json:"interestExpense"`
+	NonInterestIncome                 *int64 `json:"nonInterestIncome"`
+	OtherNonOperatingIncome           *int64 `json:"otherNonOperatingIncome"`
+	Depreciation                      *int64 `json:"depreciation"`
+	DepreciationAndAmortization       *int64 `json:"depreciationAndAmortization"`
+	IncomeBeforeTax                   *int64 `json:"incomeBeforeTax"`
+	IncomeTaxExpense                  *int64 `json:"incomeTaxExpense"`
+	InterestAndDebtExpense            *int64 `json:"interestAndDebtExpense"`
+	NetIncomeFromContinuingOperations *int64 `json:"netIncomeFromContinuingOperations"`
+	ComprehensiveIncomeNetOfTax       *int64 `json:"comprehensiveIncomeNetOfTax"`
+	EBIT                              *int64 `json:"ebit"`
+	EBITDA                            *int64 `json:"ebitda"`
+	NetIncome                         *int64 `json:"netIncome"`
+}
+
+func (r *IncomeStatementReport) UnmarshalJSON(data []byte) error {
+	var report IncomeStatementReport
+	if err := decodeFinancialReport(data, &report, &report.FinancialReport); err != nil {
+		return err
+	}
+	*r = report
+	return nil
+}
+
+type BalanceSheetReport struct {
+	FinancialReport
+	TotalAssets                            *int64 `json:"totalAssets"`
+	TotalCurrentAssets                     *int64 `json:"totalCurrentAssets"`
+	CashAndCashEquivalentsAtCarryingValue  *int64 `json:"cashAndCashEquivalentsAtCarryingValue"`
+	CashAndShortTermInvestments            *int64 `json:"cashAndShortTermInvestments"`
+	Inventory                              *int64 `json:"inventory"`
+	CurrentNetReceivables                  *int64 `json:"currentNetReceivables"`
+	TotalNonCurrentAssets                  *int64 `json:"totalNonCurrentAssets"`
+	PropertyPlantEquipment                 *int64 `json:"propertyPlantEquipment"`
+	AccumulatedDepreciationAmortizationPPE *int64 `json:"accumulatedDepreciationAmortizationPPE"`
+	IntangibleAssets                       *int64 `json:"intangibleAssets"`
+	IntangibleAssetsExcludingGoodwill      *int64 `json:"intangibleAssetsExcludingGoodwill"`
+	Goodwill                               *int64 `json:"goodwill"`
+	Investments                            *int64 `json:"investments"`
+	LongTermInvestments                    *int64 `json:"longTermInvestments"`
+	ShortTermInvestments                   *int64 `json:"shortTermInvestments"`
+	OtherCurrentAssets                     *int64 `json:"otherCurrentAssets"`
+	OtherNonCurrentAssets                  *int64 `json:"otherNonCurrentAssets"`
+	TotalLiabilities                       *int64 `json:"totalLiabilities"`
+	TotalCurrentLiabilities                *int64 `json:"totalCurrentLiabilities"`
+	CurrentAccountsPayable                 *int64 `json:"currentAccountsPayable"`
+	DeferredRevenue                        *int64 `json:"deferredRevenue"`
+	CurrentDebt                            *int64 `json:"currentDebt"`
+	ShortTermDebt                          *int64 `json:"shortTermDebt"`
+	TotalNonCurrentLiabilities             *int64 `json:"totalNonCurrentLiabilities"`
+	CapitalLeaseObligations                *int64 `json:"capitalLeaseObligations"`
+	LongTermDebt                           *int64 `json:"longTermDebt"`
+	CurrentLongTermDebt                    *int64 `json:"currentLongTermDebt"`
+	LongTermDebtNoncurrent                 *int64 `json:"longTermDebtNoncurrent"`
+	ShortLongTermDebtTotal                 *int64 `json:"shortLongTermDebtTotal"`
+	OtherCurrentLiabilities                *int64 `json:"otherCurrentLiabilities"`
+	OtherNonCurrentLiabilities             *int64 `json:"otherNonCurrentLiabilities"`
+	TotalShareholderEquity                 *int64 `json:"totalShareholderEquity"`
+	TreasuryStock                          *int64 `json:"treasuryStock"`
+	RetainedEarnings                       *int64 `json:"retainedEarnings"`
+	CommonStock                            *int64 `json:"commonStock"`
+	CommonStockSharesOutstanding           *int64 `json:"commonStockSharesOutstanding"`
+}
+
+func (r *BalanceSheetReport) UnmarshalJSON(data []byte) error {
+	var report BalanceSheetReport
+	if err := decodeFinancialReport(data, &report, &report.FinancialReport); err != nil {
+		return err
+	}
+	*r = report
+	return nil
+}
+
+type CashFlowReport struct {
+	FinancialReport
+	OperatingCashflow                                      *int64 `json:"operatingCashflow"`
+	PaymentsForOperatingActivities                         *int64 `json:"paymentsForOperatingActivities"`
+	ProceedsFromOperatingActivities                        *int64 `json:"proceedsFromOperatingActivities"`
+	ChangeInOperatingLiabilities                           *int64 `json:"changeInOperatingLiabilities"`
+	ChangeInOperatingAssets                                *int64 `json:"changeInOperatingAssets"`
+	DepreciationDepletionAndAmortization                   *int64 `json:"depreciationDepletionAndAmortization"`
+	CapitalExpenditures                                    *int64 `json:"capitalExpenditures"`
+	ChangeInReceivables                                    *int64 `json:"changeInReceivables"`
+	ChangeInInventory                                      *int64 `json:"changeInInventory"`
+	ProfitLoss                                             *int64 `json:"profitLoss"`
+	CashflowFromInvestment                                 *int64 `json:"cashflowFromInvestment"`
+	CashflowFromFinancing                                  *int64 `json:"cashflowFromFinancing"`
+	ProceedsFromRepaymentsOfShortTermDebt                  *int64 `json:"proceedsFromRepaymentsOfShortTermDebt"`
+	PaymentsForRepurchaseOfCommonStock                     *int64 `json:"paymentsForRepurchaseOfCommonStock"`
+	PaymentsForRepurchaseOfEquity                          *int64 `json:"paymentsForRepurchaseOfEquity"`
+	PaymentsForRepurchaseOfPreferredStock                  *int64 `json:"paymentsForRepurchaseOfPreferredStock"`
+	DividendPayout                                         *int64 `json:"dividendPayout"`
+	DividendPayoutCommonStock                              *int64 `json:"dividendPayoutCommonStock"`
+	DividendPayoutPreferredStock                           *int64 `json:"dividendPayoutPreferredStock"`
+	ProceedsFromIssuanceOfCommonStock                      *int64 `json:"proceedsFromIssuanceOfCommonStock"`
+	ProceedsFromIssuanceOfLongTermDebtAndCapitalSecurities *int64 `json:"proceedsFromIssuanceOfLongTermDebtAndCapitalSecuritiesNet"`
+	ProceedsFromIssuanceOfPreferredStock                   *int64 `json:"proceedsFromIssuanceOfPreferredStock"`
+	ProceedsFromRepurchaseOfEquity                         *int64 `json:"proceedsFromRepurchaseOfEquity"`
+	ProceedsFromSaleOfTreasuryStock                        *int64 `json:"proceedsFromSaleOfTreasuryStock"`
+	ChangeInCashAndCashEquivalents                         *int64 `json:"changeInCashAndCashEquivalents"`
+	ChangeInExchangeRate                                   *int64 `json:"changeInExchangeRate"`
+	NetIncome                                              *int64 `json:"netIncome"`
+}
+
+func (r *CashFlowReport) UnmarshalJSON(data []byte) error {
+	var report CashFlowReport
+	if err := decodeFinancialReport(data, &report, &report.FinancialReport); err != nil {
+		return err
+	}
+	*r = report
+	return nil
+}
+
+func decodeFinancialReport(data []byte, v interface{}, base *FinancialReport) error {
+	// Line items go through parseInt, so a fractional figure is an error rather than being truncated
+	if err := decodeJSONRecord(data, v); err != nil {
+		return err
+	}
+	if base.FiscalDateEnding.IsZero() {
+		return fmt.Errorf("missing fiscalDateEnding")
+	}
+
+	var fields map[string]string
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	typed := jsonFieldIndexes(reflect.TypeOf(v).Elem())
+	for key, value := range fields {
+		if _, ok := typed[normalizeRecordKey(key)]; ok {
+			continue
+		}
+
+		item, err := parseOptionalInt(value)
+		if err != nil {
+			return fmt.Errorf("invalid %s %q: %w", key, value, err)
+		}
+		if base.Extra == nil {
+			base.Extra = make(map[string]*int64)
+		}
+		base.Extra[key] = item
+	}
+	return nil
+}
+
+func lineItem[R statementReport](report R, item string) *int64 {
+	reportValue := reflect.ValueOf(report)
+	if index, ok := jsonFieldIndexes(reportValue.Type())[normalizeRecordKey(item)]; ok {
+		value, _ := reportValue.FieldByIndex(index).Interface().(*int64)
+		return value
+	}
+	return report.financialReport().Extra[item]
+}
+
+type FinancialStatement[R statementReport] struct {
+	Symbol           string `json:"symbol"`
+	AnnualReports    []R    `json:"annualReports"`
+	QuarterlyReports []R    `json:"quarterlyReports"`
+}
+
+type IncomeStatement = FinancialStatement[IncomeStatementReport]
+type BalanceSheet = FinancialStatement[BalanceSheetReport]
+type CashFlow = FinancialStatement[CashFlowReport]
+
+type LineItemPoint struct {
+	FiscalDateEnding time.Time
+	Value            *int64
+}
+
+func lineItemSeries[R statementReport](reports []R, item string) []LineItemPoint {
+	series := make([]LineItemPoint, 0, len(reports))
+	for _, report := range reports {
+		series = append(series, LineItemPoint{FiscalDateEnding: report.financialReport().FiscalDateEnding, Value: lineItem(report, item)})
+	}
+
+	sort.Slice(series, func(i, j int) bool {
+		return series[i].FiscalDateEnding.Before(series[j].FiscalDateEnding)
+	})
+	return series
+}
+
+func (f *FinancialStatement[R]) AnnualSeries(item string) []LineItemPoint {
+	return lineItemSeries(f.AnnualReports, item)
+}
+
+func (f *FinancialStatement[R]) QuarterlySeries(item string) []LineItemPoint {
+	return lineItemSeries(f.QuarterlyReports, item)
+}
+
+var (
+	minTrailingTwelveMonthsSpan = 255 * 24 * time.Hour
+	maxTrailingTwelveMonthsSpan = 295 * 24 * time.Hour
+)
+
+func (f *FinancialStatement[R]) TrailingTwelveMonthsSeries(item string) []LineItemPoint {
+	quarters := f.QuarterlySeries(item)
+
+	var series []LineItemPoint
+	for i := 3; i < len(quarters); i++ {
+		point := LineItemPoint{FiscalDateEnding: quarters[i].FiscalDateEnding}
+
+		// Four consecutive quarters end roughly nine months apart; anything wider means a gap in the history
+		span := quarters[i].FiscalDateEnding.Sub(quarters[i-3].FiscalDateEnding)
+		var sum int64
+		complete := span >= minTrailingTwelveMonthsSpan && span <= maxTrailingTwelveMonthsSpan
+		for _, quarter := range quarters[i-3 : i+1] {
+			if !complete || quarter.Value == nil {
+				complete = false
+				break
+			}
+			sum += *quarter.Value
+		}
+		if complete {
+			point.Value = &sum
+		}
+
+		series = append(series, point)
+	}
+	return series
+}
+
+func (f *FinancialStatement[R]) TrailingTwelveMonths(item string) (int64, error) {
+	series := f.TrailingTwelveMonthsSeries(item)
+	if len(series) == 0 {
+		return 0, fmt.Errorf("at least four quarterly reports are required for %s", item)
+	}
+
+	latest := series[len(series)-1]
+	if latest.Value == nil {
+		return 0, fmt.Errorf("missing %s or non-consecutive dates in the last four quarterly reports", item)
+	}
+	return *latest.Value, nil
+}
+
+func getFinancialStatement[R statementReport](ctx context.Context, c *Client, function string, symbol string) (*FinancialStatement[R], error) {
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%sfunction=%s&symbol=%s", c.BaseURL, function, url.QueryEscape(symbol)), nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	var res FinancialStatement[R]
+	if err = c.doJSONRequest(req, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+func (c *Client) GetIncomeStatement(ctx context.Context, symbol string) (*IncomeStatement, error) {
+	if strings.TrimSpace(symbol) == "" {
+		return nil, InValidInputError
+	}
+	res, err := getFinancialStatement[IncomeStatementReport](ctx, c, "INCOME_STATEMENT", symbol)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get income statement: %w", err)
+	}
+	return res, nil
+}
+
+func (c *Client) GetBalanceSheet(ctx context.Context, symbol string) (*BalanceSheet, error) {
+	if strings.TrimSpace(symbol) == "" {
+		return nil, InValidInputError
+	}
+	res, err := getFinancialStatement[BalanceSheetReport](ctx, c, "BALANCE_SHEET", symbol)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get balance sheet: %w", err)
+	}
+	return res, nil
+}
+
+func (c *Client) GetCashFlow(ctx context.Context, symbol string) (*CashFlow, error) {
+	if strings.TrimSpace(symbol) == "" {
+		return nil, InValidInputError
+	}
+	res, err := getFinancialStatement[CashFlowReport](ctx, c, "CASH_FLOW", symbol)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cash flow: %w", err)
+	}
+	return res, nil
+}
+
+type ReportTime string
+type EarningsOutcome string
+
+const (
+	ReportTimePreMarket  ReportTime = "pre-market"
+	ReportTimePostMarket ReportTime = "post-market"
+
+	EarningsBeat   EarningsOutcome = "beat"
+	EarningsMiss   EarningsOutcome = "miss"
+	EarningsInLine EarningsOutcome = "in-line"
+)
+
+type AnnualEarnings struct {
+	FiscalDateEnding time.Time `json:"fiscalDateEnding"`
+	ReportedEPS      *float64  `json:"reportedEPS"`
+}
+
+func (a *AnnualEarnings) UnmarshalJSON(data []byte) error {
+	var earnings AnnualEarnings
+	if err := decodeJSONRecord(data, &earnings); err != nil {
+		return err
+	}
+	*a = earnings
+	return nil
+}
+
+type QuarterlyEarnings struct {
+	FiscalDateEnding   time.Time  `json:"fiscalDateEnding"`
+	ReportedDate       time.Time  `json:"reportedDate"`
+	ReportedEPS        *float64   `json:"reportedEPS"`
+	EstimatedEPS       *float64   `json:"estimatedEPS"`
+	Surprise           *float64   `json:"surprise"`
+	SurprisePercentage *float64   `json:"surprisePercentage"`
+	ReportTime         ReportTime `json:"reportTime"`
+}
+
+func (q *QuarterlyEarnings) UnmarshalJSON(data []byte) error {
+	var earnings QuarterlyEarnings
+	if err := decodeJSONRecord(data, &earnings); err != nil {
+		return err
+	}
+	earnings.ReportTime = ReportTime(strings.ToLower(string(earnings.ReportTime)))
+	*q = earnings
+	return nil
+}
+
+func (q QuarterlyEarnings) Outcome() EarningsOutcome {
+	surprise := q.Surprise
+	if surprise == nil && q.ReportedEPS != nil && q.EstimatedEPS != nil {
+		difference := *q.ReportedEPS - *q.EstimatedEPS
+		surprise = &difference
+	}
+
+	switch {
+	case surprise == nil:
+		return ""
+	case *surprise > 0:
+		return EarningsBeat
+	case *surprise < 0:
+		return EarningsMiss
+	default:
+		return EarningsInLine
+	}
+}
+
+type EarningsResponse struct {
+	Symbol            string              `json:"symbol"`
+	AnnualEarnings    []AnnualEarnings    `json:"annualEarnings"`
+	QuarterlyEarnings []QuarterlyEarnings `json:"quarterlyEarnings"`
+}
+
+func (e *EarningsResponse) latestQuarters() []QuarterlyEarnings {
+	quarters := make([]QuarterlyEarnings, len(e.QuarterlyEarnings))
+	copy(quarters, e.QuarterlyEarnings)
+
+	sort.Slice(quarters, func(i, j int) bool {
+		return quarters[i].FiscalDateEnding.After(quarters[j].FiscalDateEnding)
+	})
+	return quarters
+}
+
+func (e *EarningsResponse) CurrentStreak() (EarningsOutcome, int) {
+	var outcome EarningsOutcome
+	var streak int
+	for _, quarter := range e.latestQuarters() {
+		current := quarter.Outcome()
+		if current == "" {
+			if streak == 0 {
+				continue
+			}
+			break
+		}
+
+		if streak > 0 && current != outcome {
+			break
+		}
+		outcome = current
+		streak++
+	}
+	return outcome, streak
+}
+
+func (e *EarningsResponse) LongestStreak(outcome EarningsOutcome) int {
+	var longest, streak int
+	for _, quarter := range e.latestQuarters() {
+		if quarter.Outcome() == outcome {
+			streak++
+			if streak > longest {
+				longest = streak
+			}
+		} else {
+			streak = 0
+		}
+	}
+	return longest
+}
+
+func (e *EarningsResponse) AverageSurprise(quarters int) (float64, float64, error) {
+	if quarters <= 0 {
+		return 0, 0, InValidInputError
+	}
+
+	latest := e.latestQuarters()
+	if len(latest) > quarters {
+		latest = latest[:quarters]
+	}
+
+	// Only the most recent quarters count, so missing data is reported instead of reaching further back
+	var surprise, surprisePercentage float64
+	var count int
+	for _, quarter := range latest {
+		if quarter.Surprise == nil || quarter.SurprisePercentage == nil {
+			continue
+		}
+		surprise += *quarter.Surprise
+		surprisePercentage += *quarter.SurprisePercentage
+		count++
+	}
+
+	if count < quarters {
+		return 0, 0, fmt.Errorf("only %d of the last %d quarters have surprise data", count, quarters)
+	}
+	return surprise / float64(count), surprisePercentage / float64(count), nil
+}
+
+func (c *Client) GetEarnings(ctx context.Context, symbol string) (*EarningsResponse, error) {
+	if strings.TrimSpace(symbol) == "" {
+		return nil, InValidInputError
+	}
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%sfunction=EARNINGS&symbol=%s", c.BaseURL, url.QueryEscape(symbol)), nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	var res EarningsResponse
+	if err = c.doJSONRequest(req, &res); err != nil {
+		return nil, fmt.Errorf("failed to get earnings: %w", err)
+	}
+	return &res, nil
+}
+
+type RevisionDirection string
+
+const (
+	RevisionUp   RevisionDirection = "up"
+	RevisionDown RevisionDirection = "down"
+	RevisionFlat RevisionDirection = "flat"
+)
+
+type EarningsEstimate struct {
+	Date                                  time.Time `json:"date"`
+	Horizon                               string    `json:"horizon"`
+	EPSEstimateAverage                    *float64  `json:"eps_estimate_average"`
+	EPSEstimateHigh                       *float64  `json:"eps_estimate_high"`
+	EPSEstimateLow                        *float64  `json:"eps_estimate_low"`
+	EPSEstimateAnalystCount               *int64    `json:"eps_estimate_analyst_count"`
+	EPSEstimateAverage7DaysAgo            *float64  `json:"eps_estimate_average_7_days_ago"`
+	EPSEstimateAverage30DaysAgo           *float64  `json:"eps_estimate_average_30_days_ago"`
+	EPSEstimateAverage60DaysAgo           *float64  `json:"eps_estimate_average_60_days_ago"`
+	EPSEstimateAverage90DaysAgo           *float64  `json:"eps_estimate_average_90_days_ago"`
+	EPSEstimateRevisionUpTrailing7Days    *int64    `json:"eps_estimate_revision_up_trailing_7_days"`
+	EPSEstimateRevisionDownTrailing7Days  *int64    `json:"eps_estimate_revision_down_trailing_7_days"`
+	EPSEstimateRevisionUpTrailing30Days   *int64    `json:"eps_estimate_revision_up_trailing_30_days"`
+	EPSEstimateRevisionDownTrailing30Days *int64    `json:"eps_estimate_revision_down_trailing_30_days"`
+	RevenueEstimateAverage                *float64  `json:"revenue_estimate_average"`
+	RevenueEstimateHigh                   *float64  `json:"revenue_estimate_high"`
+	RevenueEstimateLow                    *float64  `json:"revenue_estimate_low"`
+	RevenueEstimateAnalystCount           *int64    `json:"revenue_estimate_analyst_count"`
+	RevenueEstimateAverage7DaysAgo        *float64  `json:"revenue_estimate_average_7_days_ago"`
+	RevenueEstimateAverage30DaysAgo       *float64  `json:"revenue_estimate_average_30_days_ago"`
+	RevenueEstimateAverage60DaysAgo       *float64  `json:"revenue_estimate_average_60_days_ago"`
+	RevenueEstimateAverage90DaysAgo       *float64  `json:"revenue_estimate_average_90_days_ago"`
+}
+
+func (e *EarningsEstimate) UnmarshalJSON(data []byte) error {
+	var estimate EarningsEstimate
+	if err := decodeJSONRecord(data, &estimate); err != nil {
+		return err
+	}
+	*e = estimate
+	return nil
+}
+
+type EstimateRevision struct {
+	Days          int
+	Previous      float64
+	Current       float64
+	Delta         float64
+	PercentChange *float64
+}
+
+func (r EstimateRevision) Direction() RevisionDirection {
+	switch {
+	case r.Delta > 0:
+		return RevisionUp
+	case r.Delta < 0:
+		return RevisionDown
+	default:
+		return RevisionFlat
+	}
+}
+
+func newEstimateRevision(days int, current *float64, previous *float64) (EstimateRevision, bool) {
+	if current == nil || previous == nil {
+		return EstimateRevision{}, false
+	}
+
+	revision := EstimateRevision{
+		Days:     days,
+		Previous: *previous,
+		Current:  *current,
+		Delta:    *current - *previous,
+	}
+	if *previous != 0 {
+		percentChange := revision.Delta / math.Abs(*previous) * 100
+		revision.PercentChange = &percentChange
+	}
+	return revision, true
+}
+
+func (e EarningsEstimate) EPSRevisions() []EstimateRevision {
+	var revisions []EstimateRevision
+	for _, previous := range []struct {
+		days  int
+		value *float64
+	}{
+		{7, e.EPSEstimateAverage7DaysAgo},
+		{30, e.EPSEstimateAverage30DaysAgo},
+		{60, e.EPSEstimateAverage60DaysAgo},
+		{90, e.EPSEstimateAverage90DaysAgo},
+	} {
+		if revision, ok := newEstimateRevision(previous.days, e.EPSEstimateAverage, previous.value); ok {
+			revisions = append(revisions, revision)
+		}
+	}
+	return revisions
+}
+
+func (e EarningsEstimate) RevenueRevisions() []EstimateRevision {
+	var revisions []EstimateRevision
+	for _, previous := range []struct {
+		days  int
+		value *float64
+	}{
+		{7, e.RevenueEstimateAverage7DaysAgo},
+		{30, e.RevenueEstimateAverage30DaysAgo},
+		{60, e.RevenueEstimateAverage60DaysAgo},
+		{90, e.RevenueEstimateAverage90DaysAgo},
+	} {
+		if revision, ok := newEstimateRevision(previous.days, e.RevenueEstimateAverage, previous.value); ok {
+			revisions = append(revisions, revision)
+		}
+	}
+	return revisions
+}
+
+func (e EarningsEstimate) EPSMomentum() RevisionDirection {
+	return revisionMomentum(e.EPSRevisions())
+}
+
+func (e EarningsEstimate) RevenueMomentum() RevisionDirection {
+	return revisionMomentum(e.RevenueRevisions())
+}
+
+func revisionMomentum(revisions []EstimateRevision) RevisionDirection {
+	var up, down int
+	for _, revision := range revisions {
+		switch revision.Direction() {
+		case RevisionUp:
+			up++
+		case RevisionDown:
+			down++
+		}
+	}
+
+	switch {
+	case up > down:
+		return RevisionUp
+	case down > up:
+		return RevisionDown
+	default:
+		return RevisionFlat
+	}
+}
+
+type EarningsEstimatesResponse struct {
+	Symbol    string             `json:"symbol"`
+	Estimates []EarningsEstimate `json:"estimates"`
+}
+
+func (c *Client) GetEarningsEstimates(ctx context.Context, symbol string) (*EarningsEstimatesResponse, error) {
+	if strings.TrimSpace(symbol) == "" {
+		return nil, InValidInputError
+	}
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%sfunction=EARNINGS_ESTIMATES&symbol=%s", c.BaseURL, url.QueryEscape(symbol)), nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	var res EarningsEstimatesResponse
+	if err = c.doJSONRequest(req, &res); err != nil {
+		return nil, fmt.Errorf("failed to get earnings estimates: %w", err)
+	}
+	return &res, nil
+}
+
+type CorporateActionType string
+
+const (
+	CorporateActionDividend CorporateActionType = "dividend"
+	CorporateActionSplit    CorporateActionType = "split"
+)
+
+type Dividend struct {
+	ExDividendDate  time.Time  `json:"ex_dividend_date"`
+	DeclarationDate *time.Time `json:"declaration_date"`
+	RecordDate      *time.Time `json:"record_date"`
+	PaymentDate     *time.Time `json:"payment_date"`
+	Amount          float64    `json:"amount"`
+}
+
+func (d *Dividend) UnmarshalJSON(data []byte) error {
+	var dividend Dividend
+	if err := decodeJSONRecord(data, &dividend); err != nil {
+		return err
+	}
+	*d = dividend
+	return nil
+}
+
+type SplitRatio struct {
+	Numerator   int64
+	Denominator int64
+}
+
+func (s *SplitRatio) UnmarshalText(text []byte) error {
+	value := strings.NewReplacer(":", "/", " ", "").Replace(string(text))
+
+	ratio, ok := new(big.Rat).SetString(value)
+	if !ok || ratio.Sign() <= 0 || !ratio.Num().IsInt64() || !ratio.Denom().IsInt64() {
+		return fmt.Errorf("invalid split factor %q", string(text))
+	}
+
+	*s = SplitRatio{Numerator: ratio.Num().Int64(), Denominator: ratio.Denom().Int64()}
+	return nil
+}
+
+func (s SplitRatio) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+func (s SplitRatio) Float64() float64 {
+	if s.Denominator == 0 {
+		return 0
+	}
+	return float64(s.Numerator) / float64(s.Denominator)
+}
+
+func (s SplitRatio) String() string {
+	return fmt.Sprintf("%d:%d", s.Numerator, s.Denominator)
+}
+
+type Split struct {
+	EffectiveDate time.Time  `json:"effective_date"`
+	Factor        SplitRatio `json:"split_factor"`
+}
+
+func (s *Split) UnmarshalJSON(data []byte) error {
+	var split Split
+	if err := decodeJSONRecord(data, &split); err != nil {
+		return err
+	}
+	*s = split
+	return nil
+}
+
+type DividendsResponse struct {
+	Symbol string     `json:"symbol"`
+	Data   []Dividend `json:"data"`
+}
+
+type SplitsResponse struct {
+	Symbol string  `json:"symbol"`
+	Data   []Split `json:"data"`
+}
+
+type CorporateAction struct {
+	Date     time.Time
+	Type     CorporateActionType
+	Dividend *Dividend
+	Split    *Split
+}
+
+func MergeCorporateActions(dividends []Dividend, splits []Split) []CorporateAction {
+	actions := make([]CorporateAction, 0, len(dividends)+len(splits))
+	for i := range dividends {
+		actions = append(actions, CorporateAction{Date: dividends[i].ExDividendDate, Type: CorporateActionDividend, Dividend: &dividends[i]})
+	}
+	for i := range splits {
+		actions = append(actions, CorporateAction{Date: splits[i].EffectiveDate, Type: CorporateActionSplit, Split: &splits[i]})
+	}
+
+	sort.SliceStable(actions, func(i, j int) bool {
+		return actions[i].Date.Before(actions[j].Date)
+	})
+	return actions
+}
+
+func (c *Client) GetDividends(ctx context.Context, symbol string) (*DividendsResponse, error) {
+	if strings.TrimSpace(symbol) == "" {
+		return nil, InValidInputError
+	}
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%sfunction=DIVIDENDS&symbol=%s", c.BaseURL, url.QueryEscape(symbol)), nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	var res DividendsResponse
+	if err = c.doJSONRequest(req, &res); err != nil {
+		return nil, fmt.Errorf("failed to get dividends: %w", err)
+	}
+	return &res, nil
+}
+
+func (c *Client) GetSplits(ctx context.Context, symbol string) (*SplitsResponse, error) {
+	if strings.TrimSpace(symbol) == "" {
+		return nil, InValidInputError
+	}
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%sfunction=SPLITS&symbol=%s", c.BaseURL, url.QueryEscape(symbol)), nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	var res SplitsResponse
+	if err = c.doJSONRequest(req, &res); err != nil {
+		return nil, fmt.Errorf("failed to get splits: %w", err)
+	}
+	return &res, nil
+}
+
+func (c *Client) GetCorporateActions(ctx context.Context, symbol string) ([]CorporateAction, error) {
+	dividends, err := c.GetDividends(ctx, symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	splits, err := c.GetSplits(ctx, symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	return MergeCorporateActions(dividends.Data, splits.Data), nil
+}
+
+type earningsCalendarOptions struct {
+	Symbol  string  `url:"symbol,omitempty"`
+	Horizon Horizon `url:"horizon,omitempty"`
+}
+
+type EarningsCalendarEntry struct {
+	Symbol           string    `csv:"symbol"`
+	Name             string    `csv:"name"`
+	ReportDate       time.Time `csv:"reportDate"`
+	FiscalDateEnding time.Time `csv:"fiscalDateEnding"`
+	Estimate         *float64  `csv:"estimate"`
+	Currency         string    `csv:"currency"`
+	TimeOfTheDay     string    `csv:"timeOfTheDay"`
+}
+
+func (e EarningsCalendarEntry) CalendarEvent() CalendarEvent {
+	summary := fmt.Sprintf("%s earnings", e.Symbol)
+	if e.Estimate != nil {
+		summary = fmt.Sprintf("%s earnings (est. EPS %.2f %s)", e.Symbol, *e.Estimate, e.Currency)
+	}
+
+	description := fmt.Sprintf("%s\nFiscal period ending %s", e.Name, e.FiscalDateEnding.Format("2006-01-02"))
+	if e.TimeOfTheDay != "" {
+		description = fmt.Sprintf("%s\nReporting %s", description, e.TimeOfTheDay)
+	}
+
+	return CalendarEvent{
+		UID:         fmt.Sprintf("earnings-%s-%s@goalphavantage", e.Symbol, e.ReportDate.Format("20060102")),
+		Date:        e.ReportDate,
+		Summary:     summary,
+		Description: description,
+	}
+}
+
+type IPOCalendarEntry struct {
+	Symbol         string    `csv:"symbol"`
+	Name           string    `csv:"name"`
+	IPODate        time.Time `csv:"ipoDate"`
+	PriceRangeLow  *float64  `csv:"priceRangeLow"`
+	PriceRangeHigh *float64  `csv:"priceRangeHigh"`
+	Currency       string    `csv:"currency"`
+	Exchange       string    `csv:"exchange"`
+}
+
+func (i IPOCalendarEntry) CalendarEvent() CalendarEvent {
+	description := i.Name
+	if i.PriceRangeLow != nil && i.PriceRangeHigh != nil {
+		description = fmt.Sprintf("%s\nPrice range %.2f-%.2f %s", description, *i.PriceRangeLow, *i.PriceRangeHigh, i.Currency)
+	}
+
+	return CalendarEvent{
+		UID:         fmt.Sprintf("ipo-%s-%s@goalphavantage", i.Symbol, i.IPODate.Format("20060102")),
+		Date:        i.IPODate,
+		Summary:     fmt.Sprintf("%s IPO on %s", i.Symbol, i.Exchange),
+		Description: description,
+	}
+}
+
+func (c *Client) GetEarningsCalendar(ctx context.Context, symbol string, horizon Horizon) (*[]EarningsCalendarEntry, error) {
+	if !horizon.Valid() {
+		return nil, InValidInputError
+	}
+	options := earningsCalendarOptions{Symbol: strings.TrimSpace(symbol), Horizon: Horizon(strings.ToLower(string(horizon)))}
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%sfunction=EARNINGS_CALENDAR&%s", c.BaseURL, c.buildQuery(options)), nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	var res []EarningsCalendarEntry
+	if err = c.doCSVRequest(req, &res); err != nil {
+		return nil, fmt.Errorf("failed to get earnings calendar: %w", err)
+	}
+	return &res, nil
+}
+
+func (c *Client) GetIPOCalendar(ctx context.Context) (*[]IPOCalendarEntry, error) {
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%sfunction=IPO_CALENDAR", c.BaseURL), nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	var res []IPOCalendarEntry
+	if err = c.doCSVRequest(req, &res); err != nil {
+		return nil, fmt.Errorf("failed to get IPO calendar: %w", err)
+	}
+	return &res, nil
+}
+
+type SharesOutstanding struct {
+	Date    time.Time `json:"date"`
+	Diluted *int64    `json:"shares_outstanding_diluted"`
+	Basic   *int64    `json:"shares_outstanding_basic"`
+}
+
+func (s *SharesOutstanding) UnmarshalJSON(data []byte) error {
+	var shares SharesOutstanding
+	if err := decodeJSONRecord(data, &shares); err != nil {
+		return err
+	}
+	*s = shares
+	return nil
+}
+
+type SharesOutstandingResponse struct {
+	Symbol string              `json:"symbol"`
+	Status string              `json:"status"`
+	Data   []SharesOutstanding `json:"data"`
+}
+
+func (s *SharesOutstandingResponse) sortByDate() {
+	sort.Slice(s.Data, func(i, j int) bool {
+		return s.Data[i].Date.Before(s.Data[j].Date)
+	})
+}
+
+func (s *SharesOutstandingResponse) At(date time.Time) (SharesOutstanding, bool) {
+	index := sort.Search(len(s.Data), func(i int) bool {
+		return s.Data[i].Date.After(date)
+	})
+	if index == 0 {
+		return SharesOutstanding{}, false
+	}
+	return s.Data[index-1], true
+}
+
+func (c *Client) GetSharesOutstanding(ctx context.Context, symbol string) (*SharesOutstandingResponse, error) {
+	if strings.TrimSpace(symbol) == "" {
+		return nil, InValidInputError
+	}
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%sfunction=SHARES_OUTSTANDING&symbol=%s", c.BaseURL, url.QueryEscape(symbol)), nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	var res SharesOutstandingResponse
+	if err = c.doJSONRequest(req, &res); err != nil {
+		return nil, fmt.Errorf("failed to get shares outstanding: %w", err)
+	}
+	res.sortByDate()
+	return &res, nil
+}
@@ -0,0 +1,89 @@
+package goalphavantage
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const iCalendarLineLimit = 75
+
+type CalendarEvent struct {
+	UID         string
+	Date        time.Time
+	Summary     string
+	Description string
+}
+
+type CalendarEventSource interface {
+	CalendarEvent() CalendarEvent
+}
+
+func WriteICalendar[T CalendarEventSource](w io.Writer, name string, entries []T) error {
+	writer := bufio.NewWriter(w)
+	stamp := time.Now().UTC().Format("20060102T150405Z")
+
+	lines := []string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//goalphavantage//Alpha Vantage Calendar//EN",
+		"CALSCALE:GREGORIAN",
+		"METHOD:PUBLISH",
+	}
+	if name != "" {
+		lines = append(lines, "X-WR-CALNAME:"+escapeICalendarText(name))
+	}
+
+	for _, entry := range entries {
+		event := entry.CalendarEvent()
+		if event.Date.IsZero() {
+			continue
+		}
+
+		lines = append(lines,
+			"BEGIN:VEVENT",
+			"UID:"+escapeICalendarText(event.UID),
+			"DTSTAMP:"+stamp,
+			"DTSTART;VALUE=DATE:"+event.Date.Format("20060102"),
+			"DTEND;VALUE=DATE:"+event.Date.AddDate(0, 0, 1).Format("20060102"),
+			"SUMMARY:"+escapeICalendarText(event.Summary),
+		)
+		if event.Description != "" {
+			lines = append(lines, "DESCRIPTION:"+escapeICalendarText(event.Description))
+		}
+		lines = append(lines, "END:VEVENT")
+	}
+	lines = append(lines, "END:VCALENDAR")
+
+	for _, line := range lines {
+		if _, err := writer.WriteString(foldICalendarLine(line)); err != nil {
+			return err
+		}
+	}
+	return writer.Flush()
+}
+
+func escapeICalendarText(text string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(text)
+}
+
+func foldICalendarLine(line string) string {
+	var folded strings.Builder
+	limit := iCalendarLineLimit
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		folded.WriteString(line[:cut])
+		folded.WriteString("\r\n ")
+		line = line[cut:]
+		// Continuation lines start with a space that counts towards the limit
+		limit = iCalendarLineLimit - 1
+	}
+	folded.WriteString(line)
+	folded.WriteString("\r\n")
+	return folded.String()
+}
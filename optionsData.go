@@ -0,0 +1,350 @@
+package goalphavantage
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+type OptionType string
+
+const (
+	OptionTypeCall OptionType = "call"
+	OptionTypePut  OptionType = "put"
+)
+
+func (o OptionType) Valid() bool {
+	switch strings.ToLower(string(o)) {
+	case "call", "put":
+		return true
+	default:
+		return false
+	}
+}
+
+func (r RealtimeOptionChainOptions) Valid() bool {
+	return r.RequireGreeks.Valid() && r.Datatype.Valid()
+}
+
+type RealtimeOptionChainOptions struct {
+	RequireGreeks BoolString `url:"require_greeks,omitempty"`
+	Contract      string     `url:"contract,omitempty"`
+	Datatype      DataType   `url:"datatype,omitempty"`
+}
+
+type OptionContract struct {
+	ContractID        string     `json:"contractID" csv:"contractID"`
+	Symbol            string     `json:"symbol" csv:"symbol"`
+	Expiration        time.Time  `json:"expiration" csv:"expiration"`
+	Strike            float64    `json:"strike" csv:"strike"`
+	Type              OptionType `json:"type" csv:"type"`
+	Last              float64    `json:"last" csv:"last"`
+	Mark              float64    `json:"mark" csv:"mark"`
+	Bid               float64    `json:"bid" csv:"bid"`
+	BidSize           int64      `json:"bid_size" csv:"bid_size"`
+	Ask               float64    `json:"ask" csv:"ask"`
+	AskSize           int64      `json:"ask_size" csv:"ask_size"`
+	Volume            int64      `json:"volume" csv:"volume"`
+	OpenInterest      int64      `json:"open_interest" csv:"open_interest"`
+	Date              time.Time  `json:"date" csv:"date"`
+	ImpliedVolatility *float64   `json:"implied_volatility" csv:"implied_volatility"`
+	Delta             *float64   `json:"delta" csv:"delta"`
+	Gamma             *float64   `json:"gamma" csv:"gamma"`
+	Theta             *float64   `json:"theta" csv:"theta"`
+	Vega              *float64   `json:"vega" csv:"vega"`
+	Rho               *float64   `json:"rho" csv:"rho"`
+}
+
+func (o *OptionContract) UnmarshalJSON(data []byte) error {
+	var contract OptionContract
+	if err := decodeJSONRecord(data, &contract); err != nil {
+		return err
+	}
+	contract.Type = OptionType(strings.ToLower(string(contract.Type)))
+	*o = contract
+	return nil
+}
+
+type OptionChain []OptionContract
+
+type OptionChainResponse struct {
+	Endpoint string      `json:"endpoint"`
+	Message  string      `json:"message"`
+	Data     OptionChain `json:"data"`
+}
+
+func (o OptionChain) filter(keep func(contract OptionContract) bool) OptionChain {
+	var filtered OptionChain
+	for _, contract := range o {
+		if keep(contract) {
+			filtered = append(filtered, contract)
+		}
+	}
+	return filtered
+}
+
+func (o OptionChain) Contract(contractID string) (OptionContract, bool) {
+	for _, contract := range o {
+		if strings.EqualFold(contract.ContractID, contractID) {
+			return contract, true
+		}
+	}
+	return OptionContract{}, false
+}
+
+func (o OptionChain) Expirations() []time.Time {
+	seen := make(map[time.Time]bool)
+	var expirations []time.Time
+	for _, contract := range o {
+		if !seen[contract.Expiration] {
+			seen[contract.Expiration] = true
+			expirations = append(expirations, contract.Expiration)
+		}
+	}
+
+	sort.Slice(expirations, func(i, j int) bool {
+		return expirations[i].Before(expirations[j])
+	})
+	return expirations
+}
+
+func (o OptionChain) ByExpiration(expiration time.Time) OptionChain {
+	year, month, day := expiration.Date()
+	return o.filter(func(contract OptionContract) bool {
+		y, m, d := contract.Expiration.Date()
+		return y == year && m == month && d == day
+	})
+}
+
+func (o OptionChain) ByStrikeRange(minStrike float64, maxStrike float64) OptionChain {
+	return o.filter(func(contract OptionContract) bool {
+		return contract.Strike >= minStrike && contract.Strike <= maxStrike
+	})
+}
+
+func (o OptionChain) BySide(side OptionType) OptionChain {
+	return o.filter(func(contract OptionContract) bool {
+		return strings.EqualFold(string(contract.Type), string(side))
+	})
+}
+
+func (o OptionChain) Calls() OptionChain {
+	return o.BySide(OptionTypeCall)
+}
+
+func (o OptionChain) Puts() OptionChain {
+	return o.BySide(OptionTypePut)
+}
+
+func (c *Client) GetRealtimeOptions(ctx context.Context, symbol string, options *RealtimeOptionChainOptions) (*OptionChainResponse, error) {
+	if strings.TrimSpace(symbol) == "" || (options != nil && !options.Valid()) {
+		return nil, InValidInputError
+	}
+
+	apiURL := fmt.Sprintf("%sfunction=REALTIME_OPTIONS&symbol=%s&%s", c.BaseURL, url.QueryEscape(symbol), c.buildQuery(options))
+
+	req, err := http.NewRequest(http.MethodGet, apiURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req = req.WithContext(ctx)
+
+	var res OptionChainResponse
+	if options != nil && strings.ToLower(string(options.Datatype)) == "csv" {
+		if err := c.doCSVRequest(req, &res.Data); err != nil {
+			return nil, fmt.Errorf("failed to get realtime options: %w", err)
+		}
+	} else {
+		if err := c.doJSONRequest(req, &res); err != nil {
+			return nil, fmt.Errorf("failed to get realtime options: %w", err)
+		}
+	}
+
+	return &res, nil
+}
+
+var earliestHistoricalOptionsDate = time.Date(2008, 1, 1, 0, 0, 0, 0, time.UTC)
+
+type historicalOptionsOptions struct {
+	Symbol string `url:"symbol"`
+	Date   string `url:"date,omitempty"`
+}
+
+func (c *Client) GetHistoricalOptions(ctx context.Context, symbol string, date time.Time) (*OptionChainResponse, error) {
+	if strings.TrimSpace(symbol) == "" || (!date.IsZero() && date.Before(earliestHistoricalOptionsDate)) {
+		return nil, InValidInputError
+	}
+
+	options := historicalOptionsOptions{Symbol: symbol}
+	if !date.IsZero() {
+		options.Date = date.Format("2006-01-02")
+	}
+
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%sfunction=HISTORICAL_OPTIONS&%s", c.BaseURL, c.buildQuery(options)), nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	var res OptionChainResponse
+	if err = c.doJSONRequest(req, &res); err != nil {
+		return nil, fmt.Errorf("failed to get historical options: %w", err)
+	}
+	return &res, nil
+}
+
+type HistoricalOptionsIterator struct {
+	client  *Client
+	symbol  string
+	current time.Time
+	end     time.Time
+	date    time.Time
+	chain   OptionChain
+	err     error
+}
+
+func (c *Client) HistoricalOptionsRange(symbol string, from time.Time, to time.Time) *HistoricalOptionsIterator {
+	return &HistoricalOptionsIterator{
+		client:  c,
+		symbol:  symbol,
+		current: time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC),
+		end:     time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func (h *HistoricalOptionsIterator) Next(ctx context.Context) bool {
+	for h.err == nil && !h.current.After(h.end) {
+		date := h.current
+		h.current = h.current.AddDate(0, 0, 1)
+
+		if date.Weekday() == time.Saturday || date.Weekday() == time.Sunday {
+			continue
+		}
+
+		if err := ctx.Err(); err != nil {
+			h.err = err
+			return false
+		}
+
+		res, err := h.client.GetHistoricalOptions(ctx, h.symbol, date)
+		if err != nil {
+			h.err = err
+			return false
+		}
+
+		// Market holidays come back as empty chains
+		if len(res.Data) == 0 {
+			continue
+		}
+
+		h.date = date
+		h.chain = res.Data
+		return true
+	}
+	return false
+}
+
+func (h *HistoricalOptionsIterator) Date() time.Time {
+	return h.date
+}
+
+func (h *HistoricalOptionsIterator) Chain() OptionChain {
+	return h.chain
+}
+
+func (h *HistoricalOptionsIterator) Err() error {
+	return h.err
+}
+
+type SmilePoint struct {
+	Strike            float64
+	ImpliedVolatility float64
+}
+
+type VolatilitySurface struct {
+	Expirations       []time.Time
+	Strikes           []float64
+	ImpliedVolatility [][]*float64
+}
+
+func (o OptionChain) averageVolatilityByStrike(side OptionType) map[float64]float64 {
+	sums := make(map[float64]float64)
+	counts := make(map[float64]int)
+	for _, contract := range o {
+		if contract.ImpliedVolatility == nil || (side != "" && !strings.EqualFold(string(contract.Type), string(side))) {
+			continue
+		}
+		sums[contract.Strike] += *contract.ImpliedVolatility
+		counts[contract.Strike]++
+	}
+
+	for strike := range sums {
+		sums[strike] /= float64(counts[strike])
+	}
+	return sums
+}
+
+func (o OptionChain) Smile(expiration time.Time, side OptionType) []SmilePoint {
+	var smile []SmilePoint
+	for strike, volatility := range o.ByExpiration(expiration).averageVolatilityByStrike(side) {
+		smile = append(smile, SmilePoint{Strike: strike, ImpliedVolatility: volatility})
+	}
+
+	sort.Slice(smile, func(i, j int) bool {
+		return smile[i].Strike < smile[j].Strike
+	})
+	return smile
+}
+
+func (o OptionChain) Smiles(side OptionType) map[time.Time][]SmilePoint {
+	smiles := make(map[time.Time][]SmilePoint)
+	for _, expiration := range o.Expirations() {
+		if smile := o.Smile(expiration, side); len(smile) > 0 {
+			smiles[expiration] = smile
+		}
+	}
+	return smiles
+}
+
+func (o OptionChain) Surface(side OptionType) VolatilitySurface {
+	surface := VolatilitySurface{Expirations: o.Expirations()}
+
+	seen := make(map[float64]bool)
+	for _, contract := range o {
+		if !seen[contract.Strike] {
+			seen[contract.Strike] = true
+			surface.Strikes = append(surface.Strikes, contract.Strike)
+		}
+	}
+	sort.Float64s(surface.Strikes)
+
+	for _, expiration := range surface.Expirations {
+		volatilities := o.ByExpiration(expiration).averageVolatilityByStrike(side)
+		row := make([]*float64, len(surface.Strikes))
+		for i, strike := range surface.Strikes {
+			if volatility, ok := volatilities[strike]; ok {
+				row[i] = &volatility
+			}
+		}
+		surface.ImpliedVolatility = append(surface.ImpliedVolatility, row)
+	}
+	return surface
+}
+
+func (v VolatilitySurface) At(expiration time.Time, strike float64) *float64 {
+	for i, e := range v.Expirations {
+		if !e.Equal(expiration) {
+			continue
+		}
+		for j, s := range v.Strikes {
+			if s == strike {
+				return v.ImpliedVolatility[i][j]
+			}
+		}
+	}
+	return nil
+}
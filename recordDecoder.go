@@ -0,0 +1,255 @@
+package goalphavantage
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	timeType            = reflect.TypeOf(time.Time{})
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	defaultTimeLayouts  = []string{"2006-01-02", "2006-01-02 15:04:05", time.RFC3339}
+)
+
+func normalizeRecordKey(key string) string {
+	return strings.NewReplacer(" ", "", "_", "", "-", "").Replace(strings.ToLower(strings.TrimSpace(key)))
+}
+
+func decodeRecords(records []map[string]string, tagName string, v interface{}) error {
+	sliceValue := reflect.ValueOf(v)
+	if sliceValue.Kind() != reflect.Ptr || sliceValue.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("unsupported type %T for v", v)
+	}
+	sliceValue = sliceValue.Elem()
+
+	elemType := sliceValue.Type().Elem()
+	structType := elemType
+	if structType.Kind() == reflect.Ptr {
+		structType = structType.Elem()
+	}
+	if structType.Kind() != reflect.Struct {
+		return fmt.Errorf("unsupported type %T for v", v)
+	}
+
+	for i, record := range records {
+		normalized := make(map[string]string, len(record))
+		for key, value := range record {
+			normalized[normalizeRecordKey(key)] = value
+		}
+
+		item := reflect.New(structType)
+		if err := decodeRecord(normalized, tagName, item.Elem()); err != nil {
+			return fmt.Errorf("record %d: %w", i+1, err)
+		}
+
+		if elemType.Kind() == reflect.Ptr {
+			sliceValue.Set(reflect.Append(sliceValue, item))
+		} else {
+			sliceValue.Set(reflect.Append(sliceValue, item.Elem()))
+		}
+	}
+
+	return nil
+}
+
+func decodeJSONRecord(data []byte, v interface{}) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	record := make(map[string]string, len(fields))
+	rawRecord := make(map[string]json.RawMessage, len(fields))
+	for key, value := range fields {
+		var text string
+		if err := json.Unmarshal(value, &text); err != nil {
+			text = string(value)
+			if text == "null" {
+				text = ""
+			}
+		}
+		record[normalizeRecordKey(key)] = text
+		rawRecord[normalizeRecordKey(key)] = value
+	}
+
+	structValue := reflect.ValueOf(v).Elem()
+	if err := decodeRecord(record, "json", structValue); err != nil {
+		return err
+	}
+	return decodeNestedJSON(rawRecord, structValue)
+}
+
+func isNestedKind(kind reflect.Kind) bool {
+	return kind == reflect.Slice || kind == reflect.Map
+}
+
+func decodeNestedJSON(rawRecord map[string]json.RawMessage, structValue reflect.Value) error {
+	structType := structValue.Type()
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		fieldValue := structValue.Field(i)
+
+		tag := strings.Split(field.Tag.Get("json"), ",")[0]
+		if tag == "" || tag == "-" || field.PkgPath != "" || !isNestedKind(fieldValue.Kind()) {
+			continue
+		}
+
+		value, ok := rawRecord[normalizeRecordKey(tag)]
+		if !ok {
+			continue
+		}
+
+		if err := json.Unmarshal(value, fieldValue.Addr().Interface()); err != nil {
+			return fmt.Errorf("invalid %s: %w", tag, err)
+		}
+	}
+	return nil
+}
+
+func decodeRecord(record map[string]string, tagName string, structValue reflect.Value) error {
+	structType := structValue.Type()
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		fieldValue := structValue.Field(i)
+
+		tag := strings.Split(field.Tag.Get(tagName), ",")[0]
+		if field.Anonymous && tag == "" && field.Type.Kind() == reflect.Struct {
+			if err := decodeRecord(record, tagName, fieldValue); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if tag == "" || tag == "-" || field.PkgPath != "" || isNestedKind(fieldValue.Kind()) {
+			continue
+		}
+
+		value, ok := record[normalizeRecordKey(tag)]
+		if !ok {
+			continue
+		}
+
+		if err := setRecordField(fieldValue, value, field.Tag.Get("layout")); err != nil {
+			return fmt.Errorf("invalid %s %q: %w", tag, value, err)
+		}
+	}
+	return nil
+}
+
+func setRecordField(fieldValue reflect.Value, value string, layout string) error {
+	if fieldValue.Kind() == reflect.Ptr {
+		if isNullValue(value) {
+			fieldValue.Set(reflect.Zero(fieldValue.Type()))
+			return nil
+		}
+
+		elem := reflect.New(fieldValue.Type().Elem())
+		if err := setRecordField(elem.Elem(), value, layout); err != nil {
+			return err
+		}
+		fieldValue.Set(elem)
+		return nil
+	}
+
+	if fieldValue.Type() != timeType && fieldValue.CanAddr() && fieldValue.Addr().Type().Implements(textUnmarshalerType) {
+		if isNullValue(value) {
+			return nil
+		}
+		return fieldValue.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(strings.TrimSpace(value)))
+	}
+
+	if fieldValue.Kind() == reflect.String {
+		fieldValue.SetString(value)
+		return nil
+	}
+
+	if isNullValue(value) {
+		fieldValue.Set(reflect.Zero(fieldValue.Type()))
+		return nil
+	}
+	value = strings.TrimSpace(value)
+
+	switch fieldValue.Kind() {
+	case reflect.Float32, reflect.Float64:
+		f, err := parseFloat(strings.TrimSuffix(value, "%"))
+		if err != nil {
+			return err
+		}
+		fieldValue.SetFloat(f)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := parseInt(value)
+		if err != nil {
+			return err
+		}
+		fieldValue.SetInt(i)
+	case reflect.Bool:
+		b, err := parseBool(value)
+		if err != nil {
+			return err
+		}
+		fieldValue.SetBool(b)
+	case reflect.Struct:
+		if fieldValue.Type() != timeType {
+			return fmt.Errorf("unsupported field type %s", fieldValue.Type())
+		}
+		t, err := parseRecordTime(value, layout)
+		if err != nil {
+			return err
+		}
+		fieldValue.Set(reflect.ValueOf(t))
+	default:
+		return fmt.Errorf("unsupported field type %s", fieldValue.Type())
+	}
+	return nil
+}
+
+func parseBool(value string) (bool, error) {
+	switch strings.ToLower(value) {
+	case "yes", "y":
+		return true, nil
+	case "no", "n":
+		return false, nil
+	default:
+		return strconv.ParseBool(value)
+	}
+}
+
+func parseRecordTime(value string, layout string) (time.Time, error) {
+	layouts := defaultTimeLayouts
+	if layout != "" {
+		layouts = []string{layout}
+	}
+
+	for _, layout := range layouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognized time format")
+}
+
+func jsonFieldIndexes(structType reflect.Type) map[string][]int {
+	indexes := make(map[string][]int)
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+
+		tag := strings.Split(field.Tag.Get("json"), ",")[0]
+		if field.Anonymous && tag == "" && field.Type.Kind() == reflect.Struct {
+			for key, index := range jsonFieldIndexes(field.Type) {
+				indexes[key] = append([]int{i}, index...)
+			}
+			continue
+		}
+
+		if tag == "" || tag == "-" || field.PkgPath != "" {
+			continue
+		}
+		indexes[normalizeRecordKey(tag)] = field.Index
+	}
+	return indexes
+}
@@ -0,0 +1,184 @@
+package goalphavantage
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+const minimumNameMatchScore = 0.3
+
+type ListingMatch struct {
+	Listing Listing
+	Score   float64
+}
+
+type SymbolIndex struct {
+	listings []Listing
+	bySymbol map[string][]int
+}
+
+func NewSymbolIndex(listings []Listing) *SymbolIndex {
+	index := SymbolIndex{
+		listings: make([]Listing, len(listings)),
+		bySymbol: make(map[string][]int),
+	}
+	copy(index.listings, listings)
+
+	sort.SliceStable(index.listings, func(i, j int) bool {
+		return strings.ToUpper(index.listings[i].Symbol) < strings.ToUpper(index.listings[j].Symbol)
+	})
+
+	for i, listing := range index.listings {
+		symbol := strings.ToUpper(listing.Symbol)
+		index.bySymbol[symbol] = append(index.bySymbol[symbol], i)
+	}
+	return &index
+}
+
+func (s *SymbolIndex) Len() int {
+	return len(s.listings)
+}
+
+func (s *SymbolIndex) Listings(symbol string) []Listing {
+	var listings []Listing
+	for _, i := range s.bySymbol[strings.ToUpper(strings.TrimSpace(symbol))] {
+		listings = append(listings, s.listings[i])
+	}
+	return listings
+}
+
+func (s *SymbolIndex) Lookup(symbol string) (Listing, bool) {
+	listings := s.Listings(symbol)
+	if len(listings) == 0 {
+		return Listing{}, false
+	}
+
+	// Symbols are reused after delisting, so prefer the active and most recent listing
+	best := listings[0]
+	for _, listing := range listings[1:] {
+		if (listing.DelistingDate == nil) != (best.DelistingDate == nil) {
+			if listing.DelistingDate == nil {
+				best = listing
+			}
+			continue
+		}
+		if listing.IPODate.After(best.IPODate) {
+			best = listing
+		}
+	}
+	return best, true
+}
+
+func (s *SymbolIndex) PrefixSearch(prefix string) []Listing {
+	prefix = strings.ToUpper(strings.TrimSpace(prefix))
+	start := sort.Search(len(s.listings), func(i int) bool {
+		return strings.ToUpper(s.listings[i].Symbol) >= prefix
+	})
+
+	var listings []Listing
+	for i := start; i < len(s.listings) && strings.HasPrefix(strings.ToUpper(s.listings[i].Symbol), prefix); i++ {
+		listings = append(listings, s.listings[i])
+	}
+	return listings
+}
+
+func (s *SymbolIndex) SearchName(query string, limit int) []ListingMatch {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return nil
+	}
+
+	var matches []ListingMatch
+	for _, listing := range s.listings {
+		if score := nameMatchScore(query, strings.ToLower(listing.Name)); score >= minimumNameMatchScore {
+			matches = append(matches, ListingMatch{Listing: listing, Score: score})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Score > matches[j].Score
+	})
+
+	if limit > 0 && len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches
+}
+
+func (s *SymbolIndex) Filter(exchange string, assetType string) []Listing {
+	var listings []Listing
+	for _, listing := range s.listings {
+		if exchange != "" && !strings.EqualFold(listing.Exchange, exchange) {
+			continue
+		}
+		if assetType != "" && !strings.EqualFold(listing.AssetType, assetType) {
+			continue
+		}
+		listings = append(listings, listing)
+	}
+	return listings
+}
+
+func (s *SymbolIndex) ActiveOn(symbol string, date time.Time) bool {
+	for _, listing := range s.Listings(symbol) {
+		if listing.ActiveOn(date) {
+			return true
+		}
+	}
+	return false
+}
+
+func nameMatchScore(query string, name string) float64 {
+	switch {
+	case name == query:
+		return 1
+	case strings.HasPrefix(name, query):
+		return 0.9
+	case strings.Contains(name, query):
+		return 0.8
+	}
+
+	// Compare against windows of the name with as many words as the query so that
+	// a misspelled "appel" still scores well against "apple inc"
+	similarity := bigramSimilarity(query, name)
+	words := strings.Fields(name)
+	size := len(strings.Fields(query))
+	for i := 0; i+size <= len(words); i++ {
+		if windowSimilarity := bigramSimilarity(query, strings.Join(words[i:i+size], " ")); windowSimilarity > similarity {
+			similarity = windowSimilarity
+		}
+	}
+	return 0.7 * similarity
+}
+
+func bigramSimilarity(a string, b string) float64 {
+	aBigrams := bigrams(a)
+	bBigrams := bigrams(b)
+	if len(aBigrams) == 0 || len(bBigrams) == 0 {
+		return 0
+	}
+
+	counts := make(map[string]int)
+	for _, bigram := range bBigrams {
+		counts[bigram]++
+	}
+
+	var shared int
+	for _, bigram := range aBigrams {
+		if counts[bigram] > 0 {
+			counts[bigram]--
+			shared++
+		}
+	}
+	return 2 * float64(shared) / float64(len(aBigrams)+len(bBigrams))
+}
+
+func bigrams(value string) []string {
+	runes := []rune(value)
+	var result []string
+	for i := 0; i+1 < len(runes); i++ {
+		result = append(result, string(runes[i:i+2]))
+	}
+	return result
+}
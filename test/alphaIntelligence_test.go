@@ -2,10 +2,14 @@ package test
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"github.com/FruitPunchSamurai1961/goalphavantage"
 	"github.com/stretchr/testify/assert"
+	"net/http"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestGetNewsSentimentWithTickerOption(t *testing.T) {
@@ -87,3 +91,326 @@ func checkRankedStockList(t *testing.T, stocks []*goalphavantage.RankedStock, li
 		assert.NotEmpty(t, stock.Volume, fmt.Sprintf("Expecting non-empty volume for %s #%d", listType, i+1))
 	}
 }
+
+func TestEarningsCallTranscript(t *testing.T) {
+	apiKey, err := getApiKey()
+	assert.Nil(t, err, fmt.Sprintf("expecting nil error, got error: %v", err))
+	assert.NotEmpty(t, apiKey, "API_KEY should not be empty")
+
+	c := goalphavantage.NewClient(apiKey)
+	ctx := context.Background()
+
+	res, err := c.GetEarningsCallTranscript(ctx, "IBM", "2024Q1")
+	assert.Nil(t, err, fmt.Sprintf("expecting nil error, got error: %v", err))
+	assert.NotNil(t, res, "expecting non-nil result")
+	assert.NotEmpty(t, res.Transcript, "expecting non-empty transcript")
+
+	// Test invalid quarter
+	res, err = c.GetEarningsCallTranscript(ctx, "IBM", "2024Q5")
+	assert.Nil(t, res, "expecting nil result")
+	assert.ErrorIs(t, err, goalphavantage.InValidInputError, "expecting error to be invalid input")
+}
+
+func TestTranscriptHelpers(t *testing.T) {
+	content := `{"symbol":"IBM","quarter":"2024Q1","transcript":[{"speaker":"Operator","title":"Operator","content":"Welcome to the call.","sentiment":"0.5"},{"speaker":"Arvind Krishna","title":"Chairman and Chief Executive Officer","content":"We had a strong quarter.","sentiment":"0.8"},{"speaker":"Jane Doe","title":"Analyst","content":"Can you talk about margins?","sentiment":"0.1"}]}`
+
+	var transcript goalphavantage.EarningsCallTranscript
+	err := json.Unmarshal([]byte(content), &transcript)
+	assert.Nil(t, err, fmt.Sprintf("expecting nil error, got error: %v", err))
+	assert.InDelta(t, 0.8, *transcript.Transcript[1].Sentiment, 1e-9)
+
+	assert.Equal(t, 1, len(transcript.ByRole(goalphavantage.SpeakerRoleExecutive)), "expecting one executive segment")
+	assert.Equal(t, 1, len(transcript.ByRole(goalphavantage.SpeakerRoleAnalyst)), "expecting one analyst segment")
+	assert.Equal(t, 1, len(transcript.BySpeaker("arvind krishna")), "expecting one segment by speaker")
+	assert.Equal(t, "Operator: Welcome to the call.\n\nArvind Krishna: We had a strong quarter.\n\nJane Doe: Can you talk about margins?", transcript.FullText())
+}
+
+func TestInsiderTransactionSummaries(t *testing.T) {
+	content := `{"data":[
+{"transaction_date":"2024-03-01","ticker":"IBM","executive":"SMITH, JOHN","executive_title":"Director","security_type":"Common Stock","acquisition_or_disposal":"A","shares":"1000.0","share_price":"180.0"},
+{"transaction_date":"2024-03-05","ticker":"IBM","executive":"DOE, JANE","executive_title":"CFO","security_type":"Common Stock","acquisition_or_disposal":"A","shares":"500.0","share_price":"182.0"},
+{"transaction_date":"2024-03-05","ticker":"IBM","executive":"SMITH, JOHN","executive_title":"Director","security_type":"Common Stock","acquisition_or_disposal":"D","shares":"200.0","share_price":"182.0"},
+{"transaction_date":"2024-04-20","ticker":"IBM","executive":"DOE, JANE","executive_title":"CFO","security_type":"Stock Option","acquisition_or_disposal":"A","shares":"300.0","share_price":"None"}]}`
+
+	var res goalphavantage.InsiderTransactionsResponse
+	err := json.Unmarshal([]byte(content), &res)
+	assert.Nil(t, err, fmt.Sprintf("expecting nil error, got error: %v", err))
+	assert.Equal(t, goalphavantage.InsiderDisposal, res.Data[2].AcquisitionOrDisposal)
+	assert.Nil(t, res.Data[3].SharePrice, "expecting None to decode as nil")
+
+	byExecutive := res.SummaryByExecutive()
+	assert.InDelta(t, 800, byExecutive["SMITH, JOHN"].NetShares(), 1e-9)
+	assert.InDelta(t, 180000-36400, byExecutive["SMITH, JOHN"].NetValue(), 1e-9)
+	assert.Equal(t, 2, byExecutive["DOE, JANE"].Transactions)
+
+	windows := res.SummaryByWindow(30)
+	assert.Equal(t, 3, len(windows), "expecting one window per distinct transaction date")
+	assert.Equal(t, 2, windows[1].Insiders)
+	assert.InDelta(t, 1300, windows[1].NetShares(), 1e-9)
+	assert.Equal(t, 1, windows[2].Transactions)
+}
+
+func TestAnalyticsFixedWindowDecoding(t *testing.T) {
+	content := `{"meta_data":{"symbols":"AAPL,MSFT","min_dt":"2023-07-03","max_dt":"2023-08-31","ohlc":"Close","interval":"DAILY"},"payload":{"RETURNS_CALCULATIONS":{
+"MEAN":{"AAPL":0.0012,"MSFT":0.0008},
+"STDDEV(ANNUALIZED=TRUE)":{"AAPL":0.21,"MSFT":0.19},
+"CORRELATION":{"index":["AAPL","MSFT"],"correlation":[[1.0],[0.62,1.0]]},
+"MAX_DRAWDOWN":{"AAPL":{"max_drawdown":-0.11,"drawdown_range":{"start_drawdown":"2023-07-31","end_drawdown":"2023-08-18"}}},
+"HISTOGRAM(BINS=3)":{"AAPL":{"bin_count":[4,10,6],"bin_edges":[-0.02,-0.01,0.0,0.01]}}}}}`
+
+	var res goalphavantage.FixedWindowAnalytics
+	err := json.Unmarshal([]byte(content), &res)
+	assert.Nil(t, err, fmt.Sprintf("expecting nil error, got error: %v", err))
+	assert.Equal(t, "AAPL,MSFT", res.MetaData.Symbols)
+	assert.Equal(t, 2023, res.MetaData.MaxDate.Year())
+
+	mean, ok := res.Scalar(goalphavantage.CalculationMean, "msft")
+	assert.True(t, ok, "expecting mean for MSFT")
+	assert.InDelta(t, 0.0008, mean, 1e-12)
+
+	stddev, ok := res.Scalar(goalphavantage.StdDevCalculation(true), "AAPL")
+	assert.True(t, ok, "expecting annualized stddev for AAPL")
+	assert.InDelta(t, 0.21, stddev, 1e-12)
+
+	matrix, ok := res.Matrix(goalphavantage.CalculationCorrelation)
+	assert.True(t, ok, "expecting correlation matrix")
+	correlation, ok := matrix.Get("AAPL", "MSFT")
+	assert.True(t, ok, "expecting AAPL/MSFT correlation")
+	assert.InDelta(t, 0.62, correlation, 1e-12)
+	assert.InDelta(t, 0.62, matrix.Values[0][1], 1e-12, "expecting upper triangle to be mirrored")
+
+	assert.InDelta(t, -0.11, res.Drawdowns["AAPL"].MaxDrawdown, 1e-12)
+	assert.Equal(t, 18, res.Drawdowns["AAPL"].End.Day())
+
+	histogram, ok := res.Histogram(goalphavantage.HistogramCalculation(3), "AAPL")
+	assert.True(t, ok, "expecting histogram for AAPL")
+	assert.Equal(t, []int64{4, 10, 6}, histogram.BinCounts)
+	assert.Equal(t, 0, len(res.Raw), "expecting every calculation to decode")
+}
+
+func TestAnalyticsSlidingWindowDecoding(t *testing.T) {
+	content := `{"meta_data":{"symbols":"AAPL","min_dt":"2023-07-03","max_dt":"2023-08-31","ohlc":"Close","interval":"DAILY","window_size":10},"payload":{"RETURNS_CALCULATIONS":{
+"MEAN":{"RUNNING_MEAN":{"AAPL":{"2023-07-18":0.002,"2023-07-17":0.001}}}}}}`
+
+	var res goalphavantage.SlidingWindowAnalytics
+	err := json.Unmarshal([]byte(content), &res)
+	assert.Nil(t, err, fmt.Sprintf("expecting nil error, got error: %v", err))
+	assert.Equal(t, 10, res.MetaData.WindowSize)
+
+	series := res.SeriesFor(goalphavantage.CalculationMean, "AAPL")
+	assert.Equal(t, 2, len(series), "expecting two points")
+	assert.Equal(t, 17, series[0].Date.Day(), "expecting points sorted by date")
+	assert.InDelta(t, 0.002, series[1].Value, 1e-12)
+}
+
+func TestAnalyticsFixedWindowQuery(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		assert.Equal(t, "ANALYTICS_FIXED_WINDOW", query.Get("function"))
+		assert.Equal(t, "AAPL,MSFT", query.Get("SYMBOLS"))
+		assert.Equal(t, []string{"2023-07-01", "2023-08-31"}, query["RANGE"])
+		assert.Equal(t, "MEAN,CORRELATION(method=KENDALL)", query.Get("CALCULATIONS"))
+		_, _ = fmt.Fprint(w, `{"meta_data":{"symbols":"AAPL,MSFT"},"payload":{"RETURNS_CALCULATIONS":{"MEAN":{"AAPL":0.1,"MSFT":0.2}}}}`)
+	})
+	ctx := context.Background()
+
+	res, err := c.GetAnalyticsFixedWindow(ctx, &goalphavantage.AnalyticsFixedWindowOptions{
+		Symbols: []string{"aapl", "msft"},
+		Range: goalphavantage.AnalyticsRange{
+			Start: time.Date(2023, time.July, 1, 0, 0, 0, 0, time.UTC),
+			End:   time.Date(2023, time.August, 31, 0, 0, 0, 0, time.UTC),
+		},
+		Interval:     "DAILY",
+		Calculations: []goalphavantage.Calculation{goalphavantage.CalculationMean, goalphavantage.CorrelationCalculation(goalphavantage.CorrelationKendall)},
+	})
+	assert.Nil(t, err, fmt.Sprintf("expecting nil error, got error: %v", err))
+	mean, _ := res.Scalar(goalphavantage.CalculationMean, "AAPL")
+	assert.InDelta(t, 0.1, mean, 1e-12)
+}
+
+func TestAnalyticsInvalidInput(t *testing.T) {
+	c := goalphavantage.NewClient("demo")
+	ctx := context.Background()
+
+	_, err := c.GetAnalyticsFixedWindow(ctx, &goalphavantage.AnalyticsFixedWindowOptions{
+		Symbols:      []string{"AAPL"},
+		Range:        goalphavantage.AnalyticsRange{Relative: "2month"},
+		Interval:     "DAILY",
+		Calculations: []goalphavantage.Calculation{"SKEW"},
+	})
+	assertInvalidInputError(t, err)
+
+	_, err = c.GetAnalyticsSlidingWindow(ctx, &goalphavantage.AnalyticsSlidingWindowOptions{
+		Symbols:      []string{"AAPL"},
+		Range:        goalphavantage.AnalyticsRange{Relative: "2month"},
+		Interval:     "DAILY",
+		WindowSize:   20,
+		Calculations: []goalphavantage.Calculation{goalphavantage.CalculationMaxDrawdown},
+	})
+	assertInvalidInputError(t, err)
+}
+
+func TestNewsSentimentRangeShrinksAndDeduplicates(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	assert.Nil(t, err, fmt.Sprintf("expecting nil error, got error: %v", err))
+
+	published := []string{"20240102T090000", "20240102T100000", "20240102T110000", "20240104T120000", "20240105T000000"}
+	requests := 0
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		query := r.URL.Query()
+		assert.Equal(t, "NEWS_SENTIMENT", query.Get("function"))
+		from, _ := time.ParseInLocation("20060102T1504", query.Get("time_from"), loc)
+		to, _ := time.ParseInLocation("20060102T1504", query.Get("time_to"), loc)
+
+		var feed []string
+		for _, value := range published {
+			at, _ := time.ParseInLocation("20060102T150405", value, loc)
+			if !at.Before(from) && !at.After(to) && len(feed) < 2 {
+				feed = append(feed, fmt.Sprintf(`{"title":"%s","url":"https://example.com/%s","time_published":"%s"}`, value, value, value))
+			}
+		}
+		if len(feed) == 0 {
+			_, _ = fmt.Fprint(w, `{"Information":"No articles found. Please adjust the time range."}`)
+			return
+		}
+		_, _ = fmt.Fprintf(w, `{"items":"%d","feed":[%s]}`, len(feed), strings.Join(feed, ","))
+	})
+	ctx := context.Background()
+
+	iterator := c.NewsSentimentRange(goalphavantage.NewsSentimentRangeOptions{
+		Tickers: []string{"IBM"},
+		From:    time.Date(2024, time.January, 1, 0, 0, 0, 0, loc),
+		To:      time.Date(2024, time.January, 5, 0, 0, 0, 0, loc),
+		Window:  4 * 24 * time.Hour,
+		Limit:   2,
+	})
+
+	var titles []string
+	for iterator.Next(ctx) {
+		titles = append(titles, *iterator.Article().Title)
+	}
+	assert.Nil(t, iterator.Err(), fmt.Sprintf("expecting nil error, got error: %v", iterator.Err()))
+	assert.Equal(t, published, titles, "expecting every article once, in order")
+	assert.Greater(t, requests, 3, "expecting full windows to be split")
+}
+
+func TestNewsSentimentRangeFullMinuteWindow(t *testing.T) {
+	feed := `{"title":"a","url":"https://example.com/a","time_published":"20240102T090000"},{"title":"b","url":"https://example.com/b","time_published":"20240102T090010"}`
+	requests := 0
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		_, _ = fmt.Fprintf(w, `{"items":"2","feed":[%s]}`, feed)
+	})
+
+	loc, err := time.LoadLocation("America/New_York")
+	assert.Nil(t, err, fmt.Sprintf("expecting nil error, got error: %v", err))
+
+	iterator := c.NewsSentimentRange(goalphavantage.NewsSentimentRangeOptions{
+		Tickers: []string{"IBM"},
+		From:    time.Date(2024, time.January, 2, 9, 0, 0, 0, loc),
+		To:      time.Date(2024, time.January, 2, 10, 0, 0, 0, loc),
+		Window:  4 * time.Minute,
+		Limit:   2,
+	})
+
+	assert.False(t, iterator.Next(context.Background()), "expecting iteration to stop")
+	assert.NotNil(t, iterator.Err(), "expecting error when a one minute window is still full")
+	assert.Equal(t, 3, requests, "expecting the window to shrink to one minute before failing")
+}
+
+func TestNewsSentimentRangeCancellation(t *testing.T) {
+	c := goalphavantage.NewClient("demo")
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	iterator := c.NewsSentimentRange(goalphavantage.NewsSentimentRangeOptions{
+		From: time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+		To:   time.Date(2024, time.January, 5, 0, 0, 0, 0, time.UTC),
+	})
+	assert.False(t, iterator.Next(ctx), "expecting no articles after cancellation")
+	assert.ErrorIs(t, iterator.Err(), context.Canceled)
+
+	iterator = c.NewsSentimentRange(goalphavantage.NewsSentimentRangeOptions{
+		From: time.Date(2024, time.January, 5, 0, 0, 0, 0, time.UTC),
+		To:   time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+	})
+	assert.False(t, iterator.Next(context.Background()), "expecting no articles for an inverted range")
+	assertInvalidInputError(t, iterator.Err())
+}
+
+func TestTypedNewsArticles(t *testing.T) {
+	content := `{"items":"1","sentiment_score_definition":"x <= -0.35: Bearish","relevance_score_definition":"0 < x <= 1","feed":[{"title":"NVIDIA beats estimates","url":"https://example.com/nvda","time_published":"20240522T163000","authors":["Jane Doe"],"summary":"Strong quarter.","banner_image":null,"source":"Example","category_within_source":"n/a","source_domain":"example.com","topics":[{"topic":"Earnings","relevance_score":"0.999"}],"overall_sentiment_score":0.41,"overall_sentiment_label":"Bullish","ticker_sentiment":[{"ticker":"NVDA","relevance_score":"0.9","ticker_sentiment_score":"0.3","ticker_sentiment_label":"Somewhat_Bullish"}]}]}`
+
+	var res goalphavantage.NewsArticlesResponse
+	err := json.Unmarshal([]byte(content), &res)
+	assert.Nil(t, err, fmt.Sprintf("expecting nil error, got error: %v", err))
+	assert.Equal(t, 1, res.Items)
+
+	article := res.Feed[0]
+	loc, _ := time.LoadLocation("America/New_York")
+	assert.True(t, time.Date(2024, time.May, 22, 16, 30, 0, 0, loc).Equal(article.TimePublished), "expecting Eastern publish time")
+	assert.Equal(t, []string{"Jane Doe"}, article.Authors)
+	assert.InDelta(t, 0.999, article.Topics[0].RelevanceScore, 1e-9)
+	assert.Equal(t, goalphavantage.SentimentBullish, article.OverallSentimentLabel)
+
+	sentiment, ok := article.Ticker("nvda")
+	assert.True(t, ok, "expecting NVDA sentiment")
+	assert.InDelta(t, 0.9, sentiment.RelevanceScore, 1e-9)
+	assert.Equal(t, goalphavantage.SentimentSomewhatBullish, sentiment.SentimentLabel)
+	assert.Equal(t, goalphavantage.SentimentSomewhatBullish, goalphavantage.SentimentLabelForScore(sentiment.SentimentScore))
+
+	var legacy goalphavantage.NewsSentimentResponse
+	err = json.Unmarshal([]byte(content), &legacy)
+	assert.Nil(t, err, fmt.Sprintf("expecting nil error, got error: %v", err))
+	articles, err := legacy.Articles()
+	assert.Nil(t, err, fmt.Sprintf("expecting nil error, got error: %v", err))
+	assert.Equal(t, article, articles[0], "expecting legacy conversion to match the typed model")
+}
+
+func TestAggregateTickerSentiment(t *testing.T) {
+	loc, _ := time.LoadLocation("America/New_York")
+	articles := []goalphavantage.Article{
+		{
+			TimePublished: time.Date(2024, time.May, 22, 9, 15, 0, 0, loc),
+			TickerSentiment: []goalphavantage.ArticleTickerSentiment{
+				{Ticker: "NVDA", RelevanceScore: 0.8, SentimentScore: 0.5, SentimentLabel: goalphavantage.SentimentBullish},
+				{Ticker: "AMD", RelevanceScore: 0.05, SentimentScore: -0.5, SentimentLabel: goalphavantage.SentimentBearish},
+			},
+		},
+		{
+			TimePublished: time.Date(2024, time.May, 22, 15, 45, 0, 0, loc),
+			TickerSentiment: []goalphavantage.ArticleTickerSentiment{
+				{Ticker: "nvda", RelevanceScore: 0.2, SentimentScore: -0.1, SentimentLabel: goalphavantage.SentimentNeutral},
+			},
+		},
+		{
+			TimePublished: time.Date(2024, time.May, 24, 10, 0, 0, 0, loc),
+			TickerSentiment: []goalphavantage.ArticleTickerSentiment{
+				{Ticker: "NVDA", RelevanceScore: 0.5, SentimentScore: 0.2},
+			},
+		},
+	}
+
+	series, err := goalphavantage.AggregateTickerSentiment(articles, goalphavantage.SentimentAggregationOptions{Bucket: goalphavantage.SentimentBucketDaily, MinRelevance: 0.1})
+	assert.Nil(t, err, fmt.Sprintf("expecting nil error, got error: %v", err))
+	assert.Empty(t, series.Ticker("AMD"), "expecting low relevance mentions to be filtered")
+
+	nvda := series.Ticker("NVDA")
+	assert.Equal(t, 2, len(nvda), "expecting one point per day")
+	assert.True(t, time.Date(2024, time.May, 22, 0, 0, 0, 0, loc).Equal(nvda[0].Start))
+	assert.Equal(t, 2, nvda[0].Articles)
+	assert.InDelta(t, (0.8*0.5+0.2*-0.1)/1.0, nvda[0].Score, 1e-9)
+	assert.Equal(t, 1, nvda[0].Labels[goalphavantage.SentimentNeutral])
+	assert.Equal(t, 1, nvda[1].Labels[goalphavantage.SentimentSomewhatBullish], "expecting missing labels to be derived from the score")
+	assert.Equal(t, goalphavantage.SentimentSomewhatBullish, nvda[1].Label())
+
+	weekly, err := goalphavantage.AggregateTickerSentiment(articles, goalphavantage.SentimentAggregationOptions{Bucket: goalphavantage.SentimentBucketWeekly})
+	assert.Nil(t, err, fmt.Sprintf("expecting nil error, got error: %v", err))
+	assert.Equal(t, 1, len(weekly.Ticker("NVDA")), "expecting a single weekly point")
+	assert.Equal(t, time.Monday, weekly.Ticker("NVDA")[0].Start.Weekday())
+	assert.Equal(t, 3, weekly.Ticker("NVDA")[0].Articles)
+
+	_, err = goalphavantage.AggregateTickerSentiment(articles, goalphavantage.SentimentAggregationOptions{Bucket: "monthly"})
+	assertInvalidInputError(t, err)
+}
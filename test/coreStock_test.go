@@ -2,10 +2,14 @@ package test
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"github.com/FruitPunchSamurai1961/goalphavantage"
 	"github.com/stretchr/testify/assert"
+	"net/http"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestMonthlyAdjustedCoreStockCall(t *testing.T) {
@@ -36,3 +40,304 @@ func TestMonthlyAdjustedCoreStockCall(t *testing.T) {
 	assert.NotEmpty(t, response.MetaData.Symbol, "expecting non-empty Symbol field in MetaData")
 
 }
+
+func TestSearchSymbols(t *testing.T) {
+	apiKey, err := getApiKey()
+	assert.Nil(t, err, fmt.Sprintf("expecting nil error, got error: %v", err))
+	assert.NotEmpty(t, apiKey, "API_KEY should not be empty")
+
+	c := goalphavantage.NewClient(apiKey)
+	ctx := context.Background()
+
+	// Test JSON datatype
+	res, err := c.SearchSymbols(ctx, "tesco")
+	assert.Nil(t, err, fmt.Sprintf("expecting nil error, got error: %v", err))
+	assert.NotNil(t, res, "expecting non-nil result")
+	assert.NotEmpty(t, res.BestMatches, "expecting at least one match")
+
+	// Test CSV datatype
+	res, err = c.SearchSymbolsWithOptions(ctx, &goalphavantage.SymbolSearchOptions{Keywords: "tesco", Datatype: "csv"})
+	assert.Nil(t, err, fmt.Sprintf("expecting nil error, got error: %v", err))
+	assert.NotNil(t, res, "expecting non-nil result")
+	assert.NotEmpty(t, res.BestMatches, "expecting at least one match")
+
+	// Test empty keywords
+	res, err = c.SearchSymbols(ctx, " ")
+	assert.Nil(t, res, "expecting nil result")
+	assertInvalidInputError(t, err)
+}
+
+func TestSymbolMatchDecoding(t *testing.T) {
+	content := `{"bestMatches":[{"1. symbol":"TSCO.LON","2. name":"Tesco PLC","3. type":"Equity","4. region":"United Kingdom","5. marketOpen":"08:00","6. marketClose":"16:30","7. timezone":"UTC+01","8. currency":"GBX","9. matchScore":"0.7273"}]}`
+
+	var res goalphavantage.SymbolSearchResponse
+	err := json.Unmarshal([]byte(content), &res)
+	assert.Nil(t, err, fmt.Sprintf("expecting nil error, got error: %v", err))
+	assert.Equal(t, 1, len(res.BestMatches), "expecting one match")
+	assert.Equal(t, "TSCO.LON", res.BestMatches[0].Symbol)
+	assert.Equal(t, "United Kingdom", res.BestMatches[0].Region)
+	assert.InDelta(t, 0.7273, res.BestMatches[0].MatchScore, 1e-9)
+
+	// CSV responses decode into the same type
+	c := newTestClient(t, fixtureHandler("application/x-download", "symbol,name,type,region,marketOpen,marketClose,timezone,currency,matchScore\r\nTSCO.LON,Tesco PLC,Equity,United Kingdom,08:00,16:30,UTC+01,GBX,0.7273\r\n"))
+	csvRes, err := c.SearchSymbolsWithOptions(context.Background(), &goalphavantage.SymbolSearchOptions{Keywords: "tesco", Datatype: "csv"})
+	assert.Nil(t, err, fmt.Sprintf("expecting nil error, got error: %v", err))
+	assert.Equal(t, res.BestMatches, csvRes.BestMatches)
+}
+
+func TestGetMarketStatus(t *testing.T) {
+	apiKey, err := getApiKey()
+	assert.Nil(t, err, fmt.Sprintf("expecting nil error, got error: %v", err))
+	assert.NotEmpty(t, apiKey, "API_KEY should not be empty")
+
+	c := goalphavantage.NewClient(apiKey)
+	ctx := context.Background()
+
+	res, err := c.GetMarketStatus(ctx)
+	assert.Nil(t, err, fmt.Sprintf("expecting nil error, got error: %v", err))
+	assert.NotNil(t, res, "expecting non-nil result")
+	assert.NotEmpty(t, res.MarketsInRegion("United States"), "expecting United States markets")
+}
+
+func TestMarketStatusHelpers(t *testing.T) {
+	content := `{"endpoint":"Global Market Open & Close Status","markets":[{"market_type":"Equity","region":"United States","primary_exchanges":"NASDAQ, NYSE, AMEX, BATS","local_open":"09:30","local_close":"16:15","current_status":"open","notes":""},{"market_type":"Equity","region":"Japan","primary_exchanges":"Tokyo, Osaka","local_open":"09:00","local_close":"15:00","current_status":"closed","notes":""}]}`
+
+	var res goalphavantage.MarketStatusResponse
+	err := json.Unmarshal([]byte(content), &res)
+	assert.Nil(t, err, fmt.Sprintf("expecting nil error, got error: %v", err))
+	assert.Equal(t, []string{"NASDAQ", "NYSE", "AMEX", "BATS"}, res.Markets[0].PrimaryExchanges)
+	assert.Equal(t, goalphavantage.MarketStateOpen, res.Markets[0].CurrentStatus)
+	assert.Equal(t, goalphavantage.MarketStateClosed, res.Markets[1].CurrentStatus)
+
+	newYork, err := time.LoadLocation("America/New_York")
+	assert.Nil(t, err, fmt.Sprintf("expecting nil error, got error: %v", err))
+
+	// Open state is computed from local hours, not the status captured when the response was fetched
+	mondayMorning := time.Date(2024, 3, 11, 10, 0, 0, 0, newYork)
+	open, err := res.IsOpenAt("united states", mondayMorning)
+	assert.Nil(t, err, fmt.Sprintf("expecting nil error, got error: %v", err))
+	assert.True(t, open, "expecting United States to be open on Monday morning")
+
+	open, err = res.IsOpenAt("Japan", mondayMorning)
+	assert.Nil(t, err, fmt.Sprintf("expecting nil error, got error: %v", err))
+	assert.False(t, open, "expecting Japan to be closed at night local time")
+
+	_, err = res.IsOpen("Atlantis")
+	assert.NotNil(t, err, "expecting error for unknown region")
+
+	// Friday afternoon rolls over the weekend to Monday morning
+	now := time.Date(2024, 3, 8, 17, 0, 0, 0, newYork)
+	next, err := res.NextOpen("United States", now)
+	assert.Nil(t, err, fmt.Sprintf("expecting nil error, got error: %v", err))
+	assert.Equal(t, time.Date(2024, 3, 11, 9, 30, 0, 0, newYork), next.In(newYork))
+
+	open, err = res.Markets[0].IsOpenAt(time.Date(2024, 3, 8, 10, 0, 0, 0, newYork))
+	assert.Nil(t, err, fmt.Sprintf("expecting nil error, got error: %v", err))
+	assert.True(t, open, "expecting market to be open on Friday morning")
+
+	_, err = res.NextOpen("Atlantis", now)
+	assert.NotNil(t, err, "expecting error for unknown region")
+}
+
+func TestGetBulkQuotes(t *testing.T) {
+	apiKey, err := getApiKey()
+	assert.Nil(t, err, fmt.Sprintf("expecting nil error, got error: %v", err))
+	assert.NotEmpty(t, apiKey, "API_KEY should not be empty")
+
+	c := goalphavantage.NewClient(apiKey)
+	ctx := context.Background()
+
+	res, err := c.GetBulkQuotes(ctx, []string{"AAPL", "msft", "AAPL", "INVALID_TICKER"})
+	assert.Nil(t, err, fmt.Sprintf("expecting nil error, got error: %v", err))
+	assert.NotNil(t, res, "expecting non-nil result")
+	assert.Equal(t, 3, len(res.Quotes)+len(res.Misses), "expecting every unique symbol to be reported once")
+
+	// Test empty symbols
+	res, err = c.GetBulkQuotes(ctx, nil)
+	assert.Nil(t, res, "expecting nil result")
+	assertInvalidInputError(t, err)
+}
+
+func TestGetBulkQuotesChunking(t *testing.T) {
+	requests := 0
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		symbols := strings.Split(r.URL.Query().Get("symbol"), ",")
+		assert.LessOrEqual(t, len(symbols), 100, "expecting at most 100 symbols per request")
+
+		var rows []string
+		for _, symbol := range symbols {
+			if symbol == "SYM7" {
+				continue
+			}
+			rows = append(rows, fmt.Sprintf(`{"symbol":"%s","timestamp":"2024-04-26 16:00:00.000","open":"1","high":"1","low":"1","close":"1","volume":"10","previous_close":"1","change":"0","change_percent":"0","extended_hours_quote":"","extended_hours_change":"","extended_hours_change_percent":""}`, symbol))
+		}
+		_, _ = fmt.Fprintf(w, `{"endpoint":"Realtime Bulk Quotes","message":"","data":[%s]}`, strings.Join(rows, ","))
+	})
+	ctx := context.Background()
+
+	var symbols []string
+	for i := 0; i < 250; i++ {
+		symbols = append(symbols, fmt.Sprintf("SYM%d", i))
+	}
+	// Duplicates that would otherwise land in later chunks
+	symbols = append(symbols, "sym5", " SYM120 ", "SYM7")
+
+	res, err := c.GetBulkQuotes(ctx, symbols)
+	assert.Nil(t, err, fmt.Sprintf("expecting nil error, got error: %v", err))
+	assert.Equal(t, 3, requests, "expecting 250 unique symbols to be sent in three chunks")
+	assert.Equal(t, 249, len(res.Quotes))
+	assert.Equal(t, 1, len(res.Misses), "expecting only the symbol missing from the response to be a miss")
+	assert.ErrorIs(t, res.Misses["SYM7"], goalphavantage.QuoteNotFoundError)
+}
+
+func TestGetBulkQuotesEntitlement(t *testing.T) {
+	message := ""
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintf(w, `{"endpoint":"Realtime Bulk Quotes","message":"%s","data":[{"symbol":"IBM","timestamp":"2024-04-26 16:00:00.000","open":"1","high":"1","low":"1","close":"1","volume":"10","previous_close":"1","change":"0","change_percent":"0"}]}`, message)
+	})
+	ctx := context.Background()
+
+	res, err := c.GetBulkQuotes(ctx, []string{"IBM"})
+	assert.Nil(t, err, fmt.Sprintf("expecting nil error, got error: %v", err))
+	assert.Equal(t, goalphavantage.Entitlement(""), res.Entitlement, "expecting unknown entitlement without a marker")
+
+	message = "Realtime data"
+	res, err = c.GetBulkQuotes(ctx, []string{"IBM"})
+	assert.Nil(t, err, fmt.Sprintf("expecting nil error, got error: %v", err))
+	assert.Equal(t, goalphavantage.EntitlementRealtime, res.Entitlement)
+
+	message = "Data delayed by 15 minutes"
+	res, err = c.GetBulkQuotes(ctx, []string{"IBM"})
+	assert.Nil(t, err, fmt.Sprintf("expecting nil error, got error: %v", err))
+	assert.Equal(t, goalphavantage.EntitlementDelayed, res.Entitlement)
+}
+
+func TestGetBulkQuotesAPIError(t *testing.T) {
+	c := newTestClient(t, fixtureHandler("application/json", `{"Information":"The **demo** API key is for demo purposes only. Please claim your free API key as invalid."}`))
+
+	res, err := c.GetBulkQuotes(context.Background(), []string{"AAPL", "MSFT"})
+	assert.Nil(t, res, "expecting nil result")
+	var apiErr *goalphavantage.APIError
+	assert.ErrorAs(t, err, &apiErr, "expecting API errors to be returned to the caller")
+}
+
+func TestQuoteDecoding(t *testing.T) {
+	content := `{"symbol":"MSFT","timestamp":"2024-04-26 16:00:00.000","open":"412.1700","high":"413.0000","low":"405.7600","close":"406.3200","volume":"29694718","previous_close":"399.0400","change":"7.2800","change_percent":"1.8244","extended_hours_quote":"","extended_hours_change":"","extended_hours_change_percent":""}`
+
+	var quote goalphavantage.Quote
+	err := json.Unmarshal([]byte(content), &quote)
+	assert.Nil(t, err, fmt.Sprintf("expecting nil error, got error: %v", err))
+	assert.Equal(t, "MSFT", quote.Symbol)
+	assert.Equal(t, int64(29694718), quote.Volume)
+	assert.InDelta(t, 406.32, quote.Close, 1e-9)
+	assert.Nil(t, quote.ExtendedHoursQuote, "expecting nil extended hours quote")
+	assert.Equal(t, 16, quote.Timestamp.Hour())
+}
+
+func TestQuoteVolumeDecoding(t *testing.T) {
+	tests := []struct {
+		volume   string
+		expected int64
+		valid    bool
+	}{
+		{volume: "29694718", expected: 29694718, valid: true},
+		{volume: "1.0", expected: 1, valid: true},
+		{volume: "99.9", valid: false},
+		{volume: "9223372036854775807", expected: 9223372036854775807, valid: true},
+		{volume: "9223372036854775808", valid: false},
+		{volume: "12345678901234567890", valid: false},
+		{volume: "1.2345678901234567e19", valid: false},
+		{volume: "abc", valid: false},
+	}
+
+	for _, test := range tests {
+		content := fmt.Sprintf(`{"symbol":"MSFT","timestamp":"2024-04-26 16:00:00.000","open":"1","high":"1","low":"1","close":"1","volume":"%s","previous_close":"1","change":"0","change_percent":"0"}`, test.volume)
+
+		var quote goalphavantage.Quote
+		err := json.Unmarshal([]byte(content), &quote)
+		if !test.valid {
+			assert.NotNil(t, err, fmt.Sprintf("expecting error for volume %q", test.volume))
+			continue
+		}
+		assert.Nil(t, err, fmt.Sprintf("expecting nil error for volume %q, got error: %v", test.volume, err))
+		assert.Equal(t, test.expected, quote.Volume)
+	}
+}
+
+func TestTimeSeriesCSVDecoding(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/x-download")
+		switch r.URL.Query().Get("function") {
+		case "GLOBAL_QUOTE":
+			_, _ = fmt.Fprint(w, "symbol,open,high,low,price,volume,latestDay,previousClose,change,changePercent\r\nIBM,167.5,168.9,166.2,168.1,4120581,2024-04-26,167.0,1.1,0.6587%\r\n")
+		default:
+			_, _ = fmt.Fprint(w, "timestamp,open,high,low,close,adjusted_close,volume,dividend_amount,split_coefficient\r\n2024-04-26,167.5,168.9,166.2,168.1,168.1,4120581,0.0000,1.0\r\n2024-04-25,168.2,170.4,165.8,167.0,167.0,6542011,0.0000,1.0\r\n")
+		}
+	})
+	ctx := context.Background()
+
+	res, err := c.GetTimeSeriesStockData(ctx, &goalphavantage.CoreStockSharedInputOptions{
+		Function: "TIME_SERIES_DAILY_ADJUSTED",
+		Symbol:   "IBM",
+		Datatype: "csv",
+	})
+	assert.Nil(t, err, fmt.Sprintf("expecting nil error, got error: %v", err))
+	assert.Equal(t, 2, len(res.DailyTimeSeries), "expecting two daily rows")
+	assert.Equal(t, "168.1", *res.DailyTimeSeries["2024-04-26"].AdjustedClose)
+	assert.Equal(t, "4120581", *res.DailyTimeSeries["2024-04-26"].VolumeForAdjustedCall)
+	assert.Equal(t, "1.0", *res.DailyTimeSeries["2024-04-25"].SplitCoefficient)
+
+	res, err = c.GetTimeSeriesStockData(ctx, &goalphavantage.CoreStockSharedInputOptions{
+		Function: "GLOBAL_QUOTE",
+		Symbol:   "IBM",
+		Datatype: "csv",
+	})
+	assert.Nil(t, err, fmt.Sprintf("expecting nil error, got error: %v", err))
+	assert.NotNil(t, res.LatestQuote, "expecting non-nil quote")
+	assert.Equal(t, "2024-04-26", *res.LatestQuote.LatestTradingDay)
+}
+
+func TestCoreStockResponseBars(t *testing.T) {
+	content := `{"Meta Data":{"1. Information":"Intraday (5min) open, high, low, close prices and volume","2. Symbol":"IBM","3. Last Refreshed":"2024-04-26 19:55:00","4. Interval":"5min","5. Output Size":"Compact","6. Time Zone":"US/Eastern"},"Time Series (5min)":{"2024-04-26 19:55:00":{"1. open":"167.9800","2. high":"168.0000","3. low":"167.9800","4. close":"168.0000","5. volume":"103"},"2024-04-26 19:50:00":{"1. open":"167.9000","2. high":"167.9900","3. low":"167.9000","4. close":"167.9800","5. volume":"211"}}}`
+
+	var res goalphavantage.CoreStockResponse
+	err := json.Unmarshal([]byte(content), &res)
+	assert.Nil(t, err, fmt.Sprintf("expecting nil error, got error: %v", err))
+
+	bars, err := res.Bars()
+	assert.Nil(t, err, fmt.Sprintf("expecting nil error, got error: %v", err))
+	assert.Equal(t, 2, len(bars), "expecting two bars")
+	assert.True(t, bars[0].Time.Before(bars[1].Time), "expecting bars in chronological order")
+	assert.Equal(t, "US/Eastern", bars[0].Time.Location().String())
+	assert.Equal(t, int64(211), bars[0].Volume)
+	assert.InDelta(t, 168.0, bars[1].Close, 1e-9)
+	assert.Nil(t, bars[0].AdjustedClose, "expecting nil adjusted close")
+
+	// Test that parse errors are surfaced
+	invalid := "abc"
+	res.FiveMinTimeSeries["2024-04-26 19:55:00"].Close = &invalid
+	_, err = res.Bars()
+	assert.NotNil(t, err, "expecting error for invalid close")
+
+	// Daily and weekly series number their time zone key differently
+	daily := `{"Meta Data":{"1. Information":"Daily Prices (open, high, low, close) and Volumes","2. Symbol":"7203.T","3. Last Refreshed":"2024-04-26","4. Output Size":"Compact","5. Time Zone":"Asia/Tokyo"},"Time Series (Daily)":{"2024-04-26":{"1. open":"3500","2. high":"3550","3. low":"3480","4. close":"3520","5. volume":"1000"}}}`
+	var dailyRes goalphavantage.DailyResponse
+	err = json.Unmarshal([]byte(daily), &dailyRes)
+	assert.Nil(t, err, fmt.Sprintf("expecting nil error, got error: %v", err))
+	assert.Equal(t, "Compact", *dailyRes.MetaData.OutputSize)
+
+	bars, err = dailyRes.Bars()
+	assert.Nil(t, err, fmt.Sprintf("expecting nil error, got error: %v", err))
+	assert.Equal(t, "Asia/Tokyo", bars[0].Time.Location().String())
+
+	weekly := `{"Meta Data":{"1. Information":"Weekly Prices (open, high, low, close) and Volumes","2. Symbol":"IBM","3. Last Refreshed":"2024-04-26","4. Time Zone":"US/Pacific"},"Weekly Time Series":{"2024-04-26":{"1. open":"167","2. high":"169","3. low":"165","4. close":"168","5. volume":"1000"}}}`
+	var weeklyRes goalphavantage.CoreStockResponse
+	err = json.Unmarshal([]byte(weekly), &weeklyRes)
+	assert.Nil(t, err, fmt.Sprintf("expecting nil error, got error: %v", err))
+	assert.Equal(t, "US/Pacific", *weeklyRes.MetaData.TimeZone)
+
+	bars, err = weeklyRes.Bars()
+	assert.Nil(t, err, fmt.Sprintf("expecting nil error, got error: %v", err))
+	assert.Equal(t, "US/Pacific", bars[0].Time.Location().String())
+}
@@ -1,11 +1,16 @@
 package test
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"github.com/FruitPunchSamurai1961/goalphavantage"
 	"github.com/stretchr/testify/assert"
+	"net/http"
+	"strings"
 	"testing"
+	"time"
 )
 
 func assertNonEmptyFields(t *testing.T, listings *[]goalphavantage.Listing) {
@@ -69,3 +74,300 @@ func TestListingStatusCall(t *testing.T) {
 	assert.NotNil(t, listings, "expecting non-nil result")
 	assertNonEmptyFields(t, listings)
 }
+
+func TestGetCompanyOverview(t *testing.T) {
+	apiKey, err := getApiKey()
+	assert.Nil(t, err, fmt.Sprintf("expecting nil error, got error: %v", err))
+	assert.NotEmpty(t, apiKey, "API_KEY should not be empty")
+
+	c := goalphavantage.NewClient(apiKey)
+	ctx := context.Background()
+
+	overview, err := c.GetCompanyOverview(ctx, "IBM")
+	assert.Nil(t, err, fmt.Sprintf("expecting nil error, got error: %v", err))
+	assert.NotNil(t, overview, "expecting non-nil result")
+	assert.Equal(t, "IBM", overview.Symbol)
+	assert.NotNil(t, overview.MarketCapitalization, "expecting non-nil MarketCapitalization")
+
+	//Test empty symbol
+	overview, err = c.GetCompanyOverview(ctx, "")
+	assert.Nil(t, overview, "expecting nil result")
+	assertInvalidInputError(t, err)
+}
+
+func TestCompanyOverviewDecoding(t *testing.T) {
+	content := `{"Symbol":"IBM","Sector":"TECHNOLOGY","FiscalYearEnd":"December","LatestQuarter":"2024-03-31","MarketCapitalization":"154226819000","PERatio":"18.99","PEGRatio":"None","Beta":"-","52WeekHigh":"199.18","52WeekLow":"120.55","ForwardPE":"","DividendDate":"2024-06-10","ExDividendDate":"0000-00-00"}`
+
+	var overview goalphavantage.CompanyOverview
+	err := json.Unmarshal([]byte(content), &overview)
+	assert.Nil(t, err, fmt.Sprintf("expecting nil error, got error: %v", err))
+	assert.Equal(t, "TECHNOLOGY", overview.Sector)
+	assert.Equal(t, int64(154226819000), *overview.MarketCapitalization)
+	assert.InDelta(t, 18.99, *overview.PERatio, 1e-9)
+	assert.InDelta(t, 199.18, *overview.FiftyTwoWeekHigh, 1e-9)
+	assert.Nil(t, overview.PEGRatio, "expecting None to decode as nil")
+	assert.Nil(t, overview.Beta, "expecting - to decode as nil")
+	assert.Nil(t, overview.ForwardPE, "expecting empty value to decode as nil")
+	assert.Nil(t, overview.ExDividendDate, "expecting placeholder date to decode as nil")
+	assert.Equal(t, time.June, overview.DividendDate.Month())
+}
+
+func TestETFLookThroughExposure(t *testing.T) {
+	qqq := `{"net_assets":"288100000000","net_expense_ratio":"0.002","portfolio_turnover":"0.08","dividend_yield":"0.0056","inception_date":"1999-03-10","leveraged":"NO","sectors":[{"sector":"INFORMATION TECHNOLOGY","weight":"0.5"},{"sector":"COMMUNICATION SERVICES","weight":"0.5"}],"holdings":[{"symbol":"AAPL","description":"APPLE INC","weight":"0.6"},{"symbol":"GOOGL","description":"ALPHABET INC","weight":"0.4"}]}`
+	xlk := `{"net_assets":"71000000000","net_expense_ratio":"0.0009","portfolio_turnover":"None","dividend_yield":"0.0064","inception_date":"1998-12-16","leveraged":"NO","sectors":[{"sector":"INFORMATION TECHNOLOGY","weight":"1.0"}],"holdings":[{"symbol":"AAPL","description":"APPLE INC","weight":"0.5"},{"symbol":"MSFT","description":"MICROSOFT CORP","weight":"0.5"}]}`
+
+	profiles := make(map[string]*goalphavantage.ETFProfile)
+	for symbol, content := range map[string]string{"QQQ": qqq, "XLK": xlk} {
+		var profile goalphavantage.ETFProfile
+		err := json.Unmarshal([]byte(content), &profile)
+		assert.Nil(t, err, fmt.Sprintf("expecting nil error, got error: %v", err))
+		profiles[symbol] = &profile
+	}
+
+	assert.Equal(t, int64(288100000000), *profiles["QQQ"].NetAssets)
+	assert.False(t, profiles["QQQ"].Leveraged, "expecting non-leveraged ETF")
+	assert.Nil(t, profiles["XLK"].PortfolioTurnover, "expecting None to decode as nil")
+	assert.Equal(t, 2, len(profiles["QQQ"].Holdings), "expecting two holdings")
+
+	exposure, err := goalphavantage.LookThroughExposure(map[string]float64{"QQQ": 0.5, "XLK": 0.5}, profiles)
+	assert.Nil(t, err, fmt.Sprintf("expecting nil error, got error: %v", err))
+	assert.InDelta(t, 0.55, exposure.Holdings["AAPL"], 1e-9)
+	assert.InDelta(t, 0.25, exposure.Holdings["MSFT"], 1e-9)
+	assert.InDelta(t, 0.75, exposure.Sectors["INFORMATION TECHNOLOGY"], 1e-9)
+
+	_, err = goalphavantage.LookThroughExposure(map[string]float64{"SPY": 1}, profiles)
+	assert.NotNil(t, err, "expecting error for missing profile")
+
+	var profile goalphavantage.ETFProfile
+	err = json.Unmarshal([]byte(`{"net_assets":"n/a","net_expense_ratio":"0.0009","portfolio_turnover":"n/a","dividend_yield":"n/a","inception_date":"n/a","leveraged":"NO","sectors":[],"holdings":[]}`), &profile)
+	assert.Nil(t, err, fmt.Sprintf("expecting nil error, got error: %v", err))
+	assert.Nil(t, profile.InceptionDate, "expecting n/a date to decode as nil")
+	assert.Nil(t, profile.NetAssets, "expecting n/a value to decode as nil")
+	assert.InDelta(t, 0.0009, *profile.NetExpenseRatio, 1e-9)
+}
+
+func TestFinancialStatementHelpers(t *testing.T) {
+	content := `{"symbol":"IBM","annualReports":[{"fiscalDateEnding":"2023-12-31","reportedCurrency":"USD","totalRevenue":"61860000000","netIncome":"7502000000","otherItems":"25000000"}],"quarterlyReports":[
+{"fiscalDateEnding":"2024-03-31","reportedCurrency":"USD","totalRevenue":"14462000000","netIncome":"1575000000"},
+{"fiscalDateEnding":"2023-12-31","reportedCurrency":"USD","totalRevenue":"17381000000","netIncome":"3288000000"},
+{"fiscalDateEnding":"2023-09-30","reportedCurrency":"USD","totalRevenue":"14752000000","netIncome":"1704000000"},
+{"fiscalDateEnding":"2023-06-30","reportedCurrency":"USD","totalRevenue":"15475000000","netIncome":"None"},
+{"fiscalDateEnding":"2023-03-31","reportedCurrency":"USD","totalRevenue":"14252000000","netIncome":"927000000"}]}`
+
+	var statement goalphavantage.IncomeStatement
+	err := json.Unmarshal([]byte(content), &statement)
+	assert.Nil(t, err, fmt.Sprintf("expecting nil error, got error: %v", err))
+	assert.Equal(t, "USD", statement.AnnualReports[0].ReportedCurrency)
+	assert.Equal(t, int64(61860000000), *statement.AnnualReports[0].TotalRevenue)
+	assert.Equal(t, int64(25000000), *statement.AnnualReports[0].Extra["otherItems"], "expecting untyped line items in Extra")
+	assert.Equal(t, int64(25000000), *statement.AnnualSeries("otherItems")[0].Value, "expecting series lookup to fall back to Extra")
+	assert.Nil(t, statement.QuarterlyReports[3].NetIncome, "expecting None to decode as nil")
+
+	series := statement.QuarterlySeries("totalRevenue")
+	assert.Equal(t, 5, len(series), "expecting five quarters")
+	assert.Equal(t, time.March, series[0].FiscalDateEnding.Month())
+	assert.Equal(t, int64(14462000000), *series[4].Value)
+
+	revenue, err := statement.TrailingTwelveMonths("totalRevenue")
+	assert.Nil(t, err, fmt.Sprintf("expecting nil error, got error: %v", err))
+	assert.Equal(t, int64(14462000000+17381000000+14752000000+15475000000), revenue)
+
+	_, err = statement.TrailingTwelveMonths("netIncome")
+	assert.NotNil(t, err, "expecting error when a quarter is missing")
+
+	// 2023-06-30 is missing, so no window of four reports covers a single year
+	gapped := `{"symbol":"IBM","annualReports":[],"quarterlyReports":[
+{"fiscalDateEnding":"2024-03-31","totalRevenue":"14462000000"},
+{"fiscalDateEnding":"2023-12-31","totalRevenue":"17381000000"},
+{"fiscalDateEnding":"2023-09-30","totalRevenue":"14752000000"},
+{"fiscalDateEnding":"2023-03-31","totalRevenue":"14252000000"},
+{"fiscalDateEnding":"2022-12-31","totalRevenue":"16690000000"}]}`
+	err = json.Unmarshal([]byte(gapped), &statement)
+	assert.Nil(t, err, fmt.Sprintf("expecting nil error, got error: %v", err))
+
+	ttm := statement.TrailingTwelveMonthsSeries("totalRevenue")
+	assert.Equal(t, 2, len(ttm), "expecting a point for every window of four reports")
+	assert.Nil(t, ttm[0].Value, "expecting nil value for a window spanning a gap")
+	assert.Nil(t, ttm[1].Value, "expecting nil value for a window spanning a gap")
+
+	_, err = statement.TrailingTwelveMonths("totalRevenue")
+	assert.NotNil(t, err, "expecting error when the latest window spans a gap")
+
+	var report goalphavantage.CashFlowReport
+	err = json.Unmarshal([]byte(`{"fiscalDateEnding":"2023-12-31","reportedCurrency":"USD","operatingCashflow":"13931000000.5"}`), &report)
+	assert.NotNil(t, err, "expecting error for a fractional line item")
+}
+
+func TestEarningsSurpriseHelpers(t *testing.T) {
+	content := `{"symbol":"IBM","annualEarnings":[{"fiscalDateEnding":"2023-12-31","reportedEPS":"9.61"}],"quarterlyEarnings":[
+{"fiscalDateEnding":"2024-03-31","reportedDate":"2024-04-24","reportedEPS":"1.68","estimatedEPS":"1.6","surprise":"0.08","surprisePercentage":"5","reportTime":"post-market"},
+{"fiscalDateEnding":"2023-12-31","reportedDate":"2024-01-24","reportedEPS":"3.87","estimatedEPS":"3.77","surprise":"0.1","surprisePercentage":"3","reportTime":"post-market"},
+{"fiscalDateEnding":"2023-09-30","reportedDate":"2023-10-25","reportedEPS":"2.2","estimatedEPS":"2.13","surprise":"0.07","surprisePercentage":"1","reportTime":"post-market"},
+{"fiscalDateEnding":"2023-06-30","reportedDate":"2023-07-19","reportedEPS":"2.18","estimatedEPS":"2.3","surprise":"-0.12","surprisePercentage":"-5","reportTime":"post-market"},
+{"fiscalDateEnding":"2023-03-31","reportedDate":"2023-04-19","reportedEPS":"None","estimatedEPS":"None","surprise":"None","surprisePercentage":"None","reportTime":"post-market"}]}`
+
+	var earnings goalphavantage.EarningsResponse
+	err := json.Unmarshal([]byte(content), &earnings)
+	assert.Nil(t, err, fmt.Sprintf("expecting nil error, got error: %v", err))
+	assert.InDelta(t, 9.61, *earnings.AnnualEarnings[0].ReportedEPS, 1e-9)
+	assert.Equal(t, goalphavantage.ReportTimePostMarket, earnings.QuarterlyEarnings[0].ReportTime)
+	assert.Nil(t, earnings.QuarterlyEarnings[4].Surprise, "expecting None to decode as nil")
+
+	outcome, streak := earnings.CurrentStreak()
+	assert.Equal(t, goalphavantage.EarningsBeat, outcome)
+	assert.Equal(t, 3, streak)
+	assert.Equal(t, 1, earnings.LongestStreak(goalphavantage.EarningsMiss))
+
+	surprise, surprisePercentage, err := earnings.AverageSurprise(3)
+	assert.Nil(t, err, fmt.Sprintf("expecting nil error, got error: %v", err))
+	assert.InDelta(t, 0.25/3, surprise, 1e-9)
+	assert.InDelta(t, 3, surprisePercentage, 1e-9)
+
+	_, _, err = earnings.AverageSurprise(5)
+	assert.NotNil(t, err, "expecting error when not enough quarters have surprise data")
+
+	// A gap in recent quarters must not be filled from older history
+	earnings.QuarterlyEarnings[1].Surprise = nil
+	_, _, err = earnings.AverageSurprise(3)
+	assert.NotNil(t, err, "expecting error when one of the most recent quarters has no surprise data")
+}
+
+func TestEarningsEstimateRevisions(t *testing.T) {
+	content := `{"symbol":"IBM","estimates":[{"date":"2024-12-31","horizon":"current fiscal year","eps_estimate_average":"10.20","eps_estimate_high":"10.50","eps_estimate_low":"9.90","eps_estimate_analyst_count":"18","eps_estimate_average_7_days_ago":"10.20","eps_estimate_average_30_days_ago":"10.00","eps_estimate_average_60_days_ago":"9.80","eps_estimate_average_90_days_ago":"None","revenue_estimate_average":"63000000000","revenue_estimate_high":"64000000000","revenue_estimate_low":"62000000000","revenue_estimate_analyst_count":"15"}]}`
+
+	var res goalphavantage.EarningsEstimatesResponse
+	err := json.Unmarshal([]byte(content), &res)
+	assert.Nil(t, err, fmt.Sprintf("expecting nil error, got error: %v", err))
+
+	estimate := res.Estimates[0]
+	assert.Equal(t, int64(18), *estimate.EPSEstimateAnalystCount)
+	assert.Nil(t, estimate.EPSEstimateAverage90DaysAgo, "expecting None to decode as nil")
+
+	revisions := estimate.EPSRevisions()
+	assert.Equal(t, 3, len(revisions), "expecting revisions only where history is available")
+	assert.Equal(t, goalphavantage.RevisionFlat, revisions[0].Direction())
+	assert.Equal(t, 30, revisions[1].Days)
+	assert.InDelta(t, 0.2, revisions[1].Delta, 1e-9)
+	assert.InDelta(t, 2, *revisions[1].PercentChange, 1e-9)
+	assert.Equal(t, goalphavantage.RevisionUp, estimate.EPSMomentum())
+	assert.Empty(t, estimate.RevenueRevisions(), "expecting no revenue revisions without history")
+}
+
+func TestCorporateActions(t *testing.T) {
+	dividendsContent := `{"symbol":"AAPL","data":[{"ex_dividend_date":"2020-11-06","declaration_date":"2020-10-29","record_date":"2020-11-09","payment_date":"2020-11-12","amount":"0.205"},{"ex_dividend_date":"2020-08-07","declaration_date":"None","record_date":"None","payment_date":"2020-08-13","amount":"0.82"}]}`
+	splitsContent := `{"symbol":"AAPL","data":[{"effective_date":"2020-08-31","split_factor":"4.0000"},{"effective_date":"2014-06-09","split_factor":"7.0000"},{"effective_date":"2021-11-04","split_factor":"1.0460"}]}`
+
+	var dividends goalphavantage.DividendsResponse
+	err := json.Unmarshal([]byte(dividendsContent), &dividends)
+	assert.Nil(t, err, fmt.Sprintf("expecting nil error, got error: %v", err))
+	assert.Nil(t, dividends.Data[1].DeclarationDate, "expecting None to decode as nil")
+	assert.InDelta(t, 0.205, dividends.Data[0].Amount, 1e-9)
+
+	var splits goalphavantage.SplitsResponse
+	err = json.Unmarshal([]byte(splitsContent), &splits)
+	assert.Nil(t, err, fmt.Sprintf("expecting nil error, got error: %v", err))
+	assert.Equal(t, goalphavantage.SplitRatio{Numerator: 4, Denominator: 1}, splits.Data[0].Factor)
+	assert.Equal(t, "523:500", splits.Data[2].Factor.String())
+
+	actions := goalphavantage.MergeCorporateActions(dividends.Data, splits.Data)
+	assert.Equal(t, 5, len(actions), "expecting all actions to be merged")
+	assert.Equal(t, goalphavantage.CorporateActionSplit, actions[0].Type)
+	assert.Equal(t, goalphavantage.CorporateActionDividend, actions[1].Type)
+	assert.Equal(t, 2020, actions[2].Date.Year())
+	assert.Equal(t, time.August, actions[2].Date.Month())
+	assert.InDelta(t, 4.0, actions[2].Split.Factor.Float64(), 1e-9)
+}
+
+func TestCalendarICalendarExport(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/x-download")
+		switch r.URL.Query().Get("function") {
+		case "IPO_CALENDAR":
+			_, _ = fmt.Fprint(w, "symbol,name,ipoDate,priceRangeLow,priceRangeHigh,currency,exchange\r\nNEWCO,\"NewCo, Inc.\",2024-05-02,14.00,16.00,USD,NASDAQ\r\n")
+		default:
+			assert.Equal(t, "3month", r.URL.Query().Get("horizon"))
+			_, _ = fmt.Fprint(w, "symbol,name,reportDate,fiscalDateEnding,estimate,currency\r\nIBM,International Business Machines Corp,2024-07-24,2024-06-30,2.17,USD\r\nXYZ,XYZ Corp,2024-07-25,2024-06-30,,USD\r\n")
+		}
+	})
+	ctx := context.Background()
+
+	earnings, err := c.GetEarningsCalendar(ctx, "", "3month")
+	assert.Nil(t, err, fmt.Sprintf("expecting nil error, got error: %v", err))
+	assert.Equal(t, 2, len(*earnings), "expecting two earnings rows")
+	assert.InDelta(t, 2.17, *(*earnings)[0].Estimate, 1e-9)
+	assert.Nil(t, (*earnings)[1].Estimate, "expecting empty estimate to decode as nil")
+
+	ipos, err := c.GetIPOCalendar(ctx)
+	assert.Nil(t, err, fmt.Sprintf("expecting nil error, got error: %v", err))
+	assert.Equal(t, "NewCo, Inc.", (*ipos)[0].Name)
+
+	var buffer bytes.Buffer
+	err = goalphavantage.WriteICalendar(&buffer, "IPOs", *ipos)
+	assert.Nil(t, err, fmt.Sprintf("expecting nil error, got error: %v", err))
+
+	content := buffer.String()
+	assert.True(t, strings.HasPrefix(content, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n"), "expecting calendar header")
+	assert.Contains(t, content, "DTSTART;VALUE=DATE:20240502\r\n")
+	assert.Contains(t, content, "DTEND;VALUE=DATE:20240503\r\n")
+	assert.Contains(t, content, `DESCRIPTION:NewCo\, Inc.\nPrice range 14.00-16.00 USD`)
+	for _, line := range strings.Split(content, "\r\n") {
+		assert.LessOrEqual(t, len(line), 75, "expecting folded lines")
+	}
+
+	// Test invalid horizon
+	earnings, err = c.GetEarningsCalendar(ctx, "IBM", "1month")
+	assert.Nil(t, earnings, "expecting nil result")
+	assertInvalidInputError(t, err)
+}
+
+func TestSharesOutstandingAt(t *testing.T) {
+	c := newTestClient(t, fixtureHandler("application/json", `{"symbol":"MSFT","status":"success","data":[{"date":"2024-03-31","shares_outstanding_diluted":"7469000000","shares_outstanding_basic":"7431000000"},{"date":"2023-12-31","shares_outstanding_diluted":"7469000000","shares_outstanding_basic":"None"},{"date":"2023-09-30","shares_outstanding_diluted":"7462000000","shares_outstanding_basic":"7429000000"}]}`))
+	ctx := context.Background()
+
+	res, err := c.GetSharesOutstanding(ctx, "MSFT")
+	assert.Nil(t, err, fmt.Sprintf("expecting nil error, got error: %v", err))
+	assert.Equal(t, time.September, res.Data[0].Date.Month())
+	assert.Nil(t, res.Data[1].Basic, "expecting None to decode as nil")
+
+	shares, ok := res.At(time.Date(2024, 2, 15, 0, 0, 0, 0, time.UTC))
+	assert.True(t, ok, "expecting shares to be found")
+	assert.Equal(t, 12, int(shares.Date.Month()))
+
+	shares, ok = res.At(time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC))
+	assert.True(t, ok, "expecting shares to be found")
+	assert.Equal(t, int64(7431000000), *shares.Basic)
+
+	_, ok = res.At(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC))
+	assert.False(t, ok, "expecting no shares before the first report")
+}
+
+func TestSymbolIndex(t *testing.T) {
+	c := newTestClient(t, fixtureHandler("application/x-download", "symbol,name,exchange,assetType,ipoDate,delistingDate,status\r\n"+
+		"AAPL,Apple Inc,NASDAQ,Stock,1980-12-12,null,Active\r\n"+
+		"AAPU,Direxion Daily AAPL Bull 1.5X Shares,NASDAQ,ETF,2022-08-09,null,Active\r\n"+
+		"IBM,International Business Machines Corp,NYSE,Stock,1962-01-02,null,Active\r\n"+
+		"TWTR,Twitter Inc,NYSE,Stock,2013-11-07,2022-11-08,Delisted\r\n"))
+	ctx := context.Background()
+
+	listings, err := c.GetListingStatus(ctx, nil)
+	assert.Nil(t, err, fmt.Sprintf("expecting nil error, got error: %v", err))
+	assert.Equal(t, 1980, (*listings)[0].IPODate.Year())
+	assert.Nil(t, (*listings)[0].DelistingDate, "expecting null delisting date to decode as nil")
+	assert.Equal(t, "Delisted", (*listings)[3].Status)
+
+	index := goalphavantage.NewSymbolIndex(*listings)
+	listing, ok := index.Lookup("ibm")
+	assert.True(t, ok, "expecting IBM to be found")
+	assert.Equal(t, "NYSE", listing.Exchange)
+
+	assert.Equal(t, 2, len(index.PrefixSearch("AAP")), "expecting two symbols starting with AAP")
+	assert.Equal(t, 1, len(index.Filter("nasdaq", "etf")), "expecting one NASDAQ ETF")
+
+	matches := index.SearchName("appel", 5)
+	assert.NotEmpty(t, matches, "expecting fuzzy name matches")
+	assert.Equal(t, "AAPL", matches[0].Listing.Symbol)
+
+	assert.True(t, index.ActiveOn("TWTR", time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)), "expecting TWTR to be active in 2020")
+	assert.False(t, index.ActiveOn("TWTR", time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)), "expecting TWTR to be delisted in 2023")
+	assert.False(t, index.ActiveOn("AAPU", time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)), "expecting AAPU to be inactive before its IPO")
+}
@@ -0,0 +1,100 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/FruitPunchSamurai1961/goalphavantage"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+	"time"
+)
+
+const optionChainContent = `{"endpoint":"Realtime Options","message":"success","data":[
+{"contractID":"IBM240621C00160000","symbol":"IBM","expiration":"2024-06-21","strike":"160.00","type":"call","last":"9.50","mark":"9.60","bid":"9.40","bid_size":"12","ask":"9.80","ask_size":"8","volume":"120","open_interest":"1500","date":"2024-04-26","implied_volatility":"0.21","delta":"0.62","gamma":"0.03","theta":"-0.05","vega":"0.25","rho":"0.11"},
+{"contractID":"IBM240621P00160000","symbol":"IBM","expiration":"2024-06-21","strike":"160.00","type":"put","last":"2.10","mark":"2.15","bid":"2.05","bid_size":"20","ask":"2.25","ask_size":"15","volume":"80","open_interest":"900","date":"2024-04-26","implied_volatility":"0.23","delta":"-0.38","gamma":"0.03","theta":"-0.04","vega":"0.25","rho":"-0.09"},
+{"contractID":"IBM240719C00170000","symbol":"IBM","expiration":"2024-07-19","strike":"170.00","type":"call","last":"4.00","mark":"4.05","bid":"3.95","bid_size":"5","ask":"4.15","ask_size":"7","volume":"40","open_interest":"600","date":"2024-04-26","implied_volatility":"0.19","delta":"0.41","gamma":"0.02","theta":"-0.03","vega":"0.30","rho":"0.08"}]}`
+
+func TestGetRealtimeOptions(t *testing.T) {
+	apiKey, err := getApiKey()
+	assert.Nil(t, err, fmt.Sprintf("expecting nil error, got error: %v", err))
+	assert.NotEmpty(t, apiKey, "API_KEY should not be empty")
+
+	c := goalphavantage.NewClient(apiKey)
+	ctx := context.Background()
+
+	res, err := c.GetRealtimeOptions(ctx, "IBM", &goalphavantage.RealtimeOptionChainOptions{RequireGreeks: "true"})
+	assert.Nil(t, err, fmt.Sprintf("expecting nil error, got error: %v", err))
+	assert.NotNil(t, res, "expecting non-nil result")
+
+	// Test empty symbol
+	res, err = c.GetRealtimeOptions(ctx, "", nil)
+	assert.Nil(t, res, "expecting nil result")
+	assertInvalidInputError(t, err)
+}
+
+func TestOptionChainHelpers(t *testing.T) {
+	var res goalphavantage.OptionChainResponse
+	err := json.Unmarshal([]byte(optionChainContent), &res)
+	assert.Nil(t, err, fmt.Sprintf("expecting nil error, got error: %v", err))
+	assert.Equal(t, 3, len(res.Data), "expecting three contracts")
+
+	contract, ok := res.Data.Contract("IBM240621P00160000")
+	assert.True(t, ok, "expecting contract to be found")
+	assert.Equal(t, goalphavantage.OptionTypePut, contract.Type)
+	assert.Equal(t, int64(900), contract.OpenInterest)
+	assert.InDelta(t, -0.38, *contract.Delta, 1e-9)
+
+	june := time.Date(2024, 6, 21, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, 2, len(res.Data.Expirations()), "expecting two expirations")
+	assert.Equal(t, 2, len(res.Data.ByExpiration(june)), "expecting two June contracts")
+	assert.Equal(t, 1, len(res.Data.ByExpiration(june).Calls()), "expecting one June call")
+	assert.Equal(t, 1, len(res.Data.ByStrikeRange(165, 175)), "expecting one contract between 165 and 175")
+}
+
+func TestVolatilitySurface(t *testing.T) {
+	var res goalphavantage.OptionChainResponse
+	err := json.Unmarshal([]byte(optionChainContent), &res)
+	assert.Nil(t, err, fmt.Sprintf("expecting nil error, got error: %v", err))
+
+	june := time.Date(2024, 6, 21, 0, 0, 0, 0, time.UTC)
+	july := time.Date(2024, 7, 19, 0, 0, 0, 0, time.UTC)
+
+	smile := res.Data.Smile(june, "")
+	assert.Equal(t, 1, len(smile), "expecting a single strike in the June smile")
+	assert.InDelta(t, 0.22, smile[0].ImpliedVolatility, 1e-9)
+
+	smiles := res.Data.Smiles(goalphavantage.OptionTypeCall)
+	assert.Equal(t, 2, len(smiles), "expecting a call smile per expiration")
+
+	surface := res.Data.Surface(goalphavantage.OptionTypeCall)
+	assert.Equal(t, []float64{160, 170}, surface.Strikes)
+	assert.InDelta(t, 0.21, *surface.At(june, 160), 1e-9)
+	assert.Nil(t, surface.At(july, 160), "expecting no July call at 160")
+}
+
+func TestHistoricalOptionsRange(t *testing.T) {
+	var dates []string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		date := r.URL.Query().Get("date")
+		dates = append(dates, date)
+		w.Header().Set("Content-Type", "application/json")
+		if date == "2024-04-29" {
+			_, _ = fmt.Fprint(w, `{"endpoint":"Historical Options","message":"success","data":[]}`)
+			return
+		}
+		_, _ = fmt.Fprint(w, optionChainContent)
+	})
+	ctx := context.Background()
+
+	var chainDates []string
+	it := c.HistoricalOptionsRange("IBM", time.Date(2024, 4, 26, 0, 0, 0, 0, time.UTC), time.Date(2024, 4, 30, 0, 0, 0, 0, time.UTC))
+	for it.Next(ctx) {
+		chainDates = append(chainDates, it.Date().Format("2006-01-02"))
+		assert.Equal(t, 3, len(it.Chain()), "expecting three contracts")
+	}
+	assert.Nil(t, it.Err(), fmt.Sprintf("expecting nil error, got error: %v", it.Err()))
+	assert.Equal(t, []string{"2024-04-26", "2024-04-29", "2024-04-30"}, dates)
+	assert.Equal(t, []string{"2024-04-26", "2024-04-30"}, chainDates)
+}
@@ -1,8 +1,12 @@
 package test
 
 import (
+	"github.com/FruitPunchSamurai1961/goalphavantage"
 	"github.com/joho/godotenv"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"testing"
 )
 
 func getApiKey() (string, error) {
@@ -13,3 +17,19 @@ func getApiKey() (string, error) {
 	apiKey := os.Getenv("API_KEY")
 	return apiKey, nil
 }
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *goalphavantage.Client {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	c := goalphavantage.NewClient("demo")
+	c.BaseURL = server.URL + "/query?"
+	return c
+}
+
+func fixtureHandler(contentType string, body string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", contentType)
+		_, _ = w.Write([]byte(body))
+	}
+}
@@ -0,0 +1,195 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/FruitPunchSamurai1961/goalphavantage"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func TestGetDaily(t *testing.T) {
+	apiKey, err := getApiKey()
+	assert.Nil(t, err, fmt.Sprintf("expecting nil error, got error: %v", err))
+	assert.NotEmpty(t, apiKey, "API_KEY should not be empty")
+
+	c := goalphavantage.NewClient(apiKey)
+	ctx := context.Background()
+
+	res, err := c.GetDaily(ctx, &goalphavantage.DailyOptions{Symbol: "IBM"})
+	assert.Nil(t, err, fmt.Sprintf("expecting nil error, got error: %v", err))
+	assert.NotNil(t, res, "expecting non-nil result")
+	assert.NotEmpty(t, res.TimeSeries, "expecting non-empty TimeSeries")
+
+	quote, err := c.GetQuote(ctx, &goalphavantage.QuoteOptions{Symbol: "IBM"})
+	assert.Nil(t, err, fmt.Sprintf("expecting nil error, got error: %v", err))
+	assert.NotNil(t, quote.Quote, "expecting non-nil Quote")
+
+	// Test missing interval for intraday
+	intraday, err := c.GetIntraday(ctx, &goalphavantage.IntradayOptions{Symbol: "IBM"})
+	assert.Nil(t, intraday, "expecting nil result")
+	assertInvalidInputError(t, err)
+}
+
+func TestIntradayResponseDecoding(t *testing.T) {
+	content := `{"Meta Data":{"2. Symbol":"IBM","4. Interval":"15min","6. Time Zone":"US/Eastern"},"Time Series (15min)":{"2024-04-26 19:45:00":{"1. open":"167.9800","2. high":"168.0000","3. low":"167.9800","4. close":"168.0000","5. volume":"103"}}}`
+
+	var res goalphavantage.IntradayResponse
+	err := json.Unmarshal([]byte(content), &res)
+	assert.Nil(t, err, fmt.Sprintf("expecting nil error, got error: %v", err))
+	assert.Equal(t, "IBM", *res.MetaData.Symbol)
+	assert.Equal(t, 1, len(res.TimeSeries), "expecting a single bar")
+
+	bars, err := res.Bars()
+	assert.Nil(t, err, fmt.Sprintf("expecting nil error, got error: %v", err))
+	assert.Equal(t, int64(103), bars[0].Volume)
+}
+
+func TestBackfillIntraday(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Query().Get("month") {
+		case "2024-01":
+			_, _ = fmt.Fprint(w, `{"Meta Data":{"6. Time Zone":"US/Eastern"},"Time Series (60min)":{"2024-01-31 19:00:00":{"1. open":"1","2. high":"1","3. low":"1","4. close":"1","5. volume":"10"},"2024-01-31 18:00:00":{"1. open":"1","2. high":"1","3. low":"1","4. close":"1","5. volume":"10"}}}`)
+		default:
+			_, _ = fmt.Fprint(w, `{"Meta Data":{"6. Time Zone":"US/Eastern"},"Time Series (60min)":{"2024-02-01 04:00:00":{"1. open":"2","2. high":"2","3. low":"2","4. close":"2","5. volume":"20"},"2024-01-31 19:00:00":{"1. open":"1","2. high":"1","3. low":"1","4. close":"1","5. volume":"10"}}}`)
+		}
+	})
+	ctx := context.Background()
+
+	var progress []string
+	bars, err := c.BackfillIntraday(ctx, &goalphavantage.IntradayBackfillOptions{
+		Symbol:   "IBM",
+		Interval: "60min",
+		From:     "2024-01",
+		To:       "2024-02",
+		Progress: func(month string, completed int, total int) {
+			progress = append(progress, fmt.Sprintf("%s %d/%d", month, completed, total))
+		},
+	})
+	assert.Nil(t, err, fmt.Sprintf("expecting nil error, got error: %v", err))
+	assert.Equal(t, 3, len(bars), "expecting duplicate bars to be merged")
+	assert.Equal(t, 4, bars[2].Time.Hour())
+	assert.Equal(t, []string{"2024-01 1/2", "2024-02 2/2"}, progress)
+
+	// Test invalid month range
+	bars, err = c.BackfillIntraday(ctx, &goalphavantage.IntradayBackfillOptions{
+		Symbol:   "IBM",
+		Interval: "60min",
+		From:     "1999-12",
+		To:       "2024-02",
+	})
+	assert.Nil(t, bars, "expecting nil result")
+	assertInvalidInputError(t, err)
+}
+
+func TestQuoteEntitlement(t *testing.T) {
+	var entitlements []string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		entitlements = append(entitlements, r.URL.Query().Get("entitlement"))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{"Global Quote - DATA DELAYED BY 15 MINUTES":{"01. symbol":"IBM","05. price":"168.0000"}}`)
+	})
+	c.Entitlement = goalphavantage.EntitlementRealtime
+	ctx := context.Background()
+
+	// Test client default and per-call override
+	res, err := c.GetQuote(ctx, &goalphavantage.QuoteOptions{Symbol: "IBM"})
+	assert.Nil(t, err, fmt.Sprintf("expecting nil error, got error: %v", err))
+	assert.Equal(t, "168.0000", *res.Quote.Price)
+	assert.Equal(t, goalphavantage.EntitlementDelayed, res.Quote.Entitlement)
+
+	_, err = c.GetQuote(ctx, &goalphavantage.QuoteOptions{Symbol: "IBM", Entitlement: goalphavantage.EntitlementDelayed})
+	assert.Nil(t, err, fmt.Sprintf("expecting nil error, got error: %v", err))
+	assert.Equal(t, []string{"realtime", "delayed"}, entitlements)
+
+	// Test invalid entitlement
+	res, err = c.GetQuote(ctx, &goalphavantage.QuoteOptions{Symbol: "IBM", Entitlement: "invalid"})
+	assert.Nil(t, res, "expecting nil result")
+	assertInvalidInputError(t, err)
+}
+
+func TestIntradayEntitlementFromMetaData(t *testing.T) {
+	information := "Intraday (5min) open, high, low, close prices and volume"
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"Meta Data":{"1. Information":"%s","2. Symbol":"IBM","6. Time Zone":"US/Eastern"},"Time Series (5min)":{"2024-04-26 19:55:00":{"1. open":"167.98","2. high":"168.00","3. low":"167.98","4. close":"168.00","5. volume":"103"}}}`, information)
+	})
+	c.Entitlement = goalphavantage.EntitlementRealtime
+	ctx := context.Background()
+
+	// Requesting realtime data does not mean realtime data was returned
+	res, err := c.GetIntraday(ctx, &goalphavantage.IntradayOptions{Symbol: "IBM", Interval: "5min"})
+	assert.Nil(t, err, fmt.Sprintf("expecting nil error, got error: %v", err))
+	assert.Equal(t, goalphavantage.Entitlement(""), res.Entitlement, "expecting unknown entitlement without a marker")
+
+	information = "Intraday (5min) open, high, low, close prices and volume (15-minute delayed)"
+	res, err = c.GetIntraday(ctx, &goalphavantage.IntradayOptions{Symbol: "IBM", Interval: "5min"})
+	assert.Nil(t, err, fmt.Sprintf("expecting nil error, got error: %v", err))
+	assert.Equal(t, goalphavantage.EntitlementDelayed, res.Entitlement)
+}
+
+func TestDailyEntitlement(t *testing.T) {
+	var entitlements []string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		entitlements = append(entitlements, r.URL.Query().Get("entitlement"))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{"Meta Data":{"1. Information":"Daily Prices (open, high, low, close) and Volumes (15-minute delayed)","2. Symbol":"IBM","5. Time Zone":"US/Eastern"},"Time Series (Daily)":{"2024-04-26":{"1. open":"167","2. high":"169","3. low":"165","4. close":"168","5. volume":"1000"}}}`)
+	})
+	c.Entitlement = goalphavantage.EntitlementRealtime
+	ctx := context.Background()
+
+	// Test client default and per-call override
+	res, err := c.GetDaily(ctx, &goalphavantage.DailyOptions{Symbol: "IBM"})
+	assert.Nil(t, err, fmt.Sprintf("expecting nil error, got error: %v", err))
+	assert.Equal(t, goalphavantage.EntitlementDelayed, res.Entitlement)
+
+	_, err = c.GetMonthlyAdjusted(ctx, &goalphavantage.MonthlyAdjustedOptions{Symbol: "IBM", Entitlement: goalphavantage.EntitlementDelayed})
+	assert.Nil(t, err, fmt.Sprintf("expecting nil error, got error: %v", err))
+	assert.Equal(t, []string{"realtime", "delayed"}, entitlements)
+
+	_, err = c.GetWeekly(ctx, &goalphavantage.WeeklyOptions{Symbol: "IBM", Entitlement: "invalid"})
+	assertInvalidInputError(t, err)
+}
+
+func TestShimMatchesDedicatedMethods(t *testing.T) {
+	var queries []string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{"Meta Data":{"1. Information":"Weekly Adjusted Prices and Volumes","2. Symbol":"IBM","4. Time Zone":"US/Eastern"},"Weekly Adjusted Time Series":{"2024-04-26":{"1. open":"167","2. high":"169","3. low":"165","4. close":"168","5. adjusted close":"168","6. volume":"1000","7. dividend amount":"0.0000"}}}`)
+	})
+	c.Entitlement = goalphavantage.EntitlementDelayed
+	ctx := context.Background()
+
+	res, err := c.GetWeeklyAdjusted(ctx, &goalphavantage.WeeklyAdjustedOptions{Symbol: "IBM"})
+	assert.Nil(t, err, fmt.Sprintf("expecting nil error, got error: %v", err))
+
+	shim, err := c.GetTimeSeriesStockData(ctx, &goalphavantage.CoreStockSharedInputOptions{Function: "TIME_SERIES_WEEKLY_ADJUSTED", Symbol: "IBM"})
+	assert.Nil(t, err, fmt.Sprintf("expecting nil error, got error: %v", err))
+
+	assert.Equal(t, queries[0], queries[1], "expecting both APIs to send the same request")
+	assert.Equal(t, res.TimeSeries, shim.WeeklyAdjustedTimeSeries)
+	assert.Equal(t, res.Entitlement, shim.Entitlement)
+}
+
+func TestQuoteEntitlementMarkers(t *testing.T) {
+	tests := []struct {
+		key      string
+		expected goalphavantage.Entitlement
+	}{
+		{key: "Global Quote - DATA DELAYED BY 15 MINUTES", expected: goalphavantage.EntitlementDelayed},
+		{key: "Global Quote - REALTIME", expected: goalphavantage.EntitlementRealtime},
+		{key: "Global Quote", expected: ""},
+	}
+
+	for _, test := range tests {
+		var res goalphavantage.QuoteResponse
+		err := json.Unmarshal([]byte(fmt.Sprintf(`{"%s":{"01. symbol":"IBM","05. price":"168.0000"}}`, test.key)), &res)
+		assert.Nil(t, err, fmt.Sprintf("expecting nil error, got error: %v", err))
+		assert.Equal(t, test.expected, res.Entitlement, fmt.Sprintf("unexpected entitlement for %q", test.key))
+		assert.Equal(t, test.expected, res.Quote.Entitlement, fmt.Sprintf("unexpected quote entitlement for %q", test.key))
+	}
+}
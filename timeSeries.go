@@ -0,0 +1,524 @@
+package goalphavantage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+func validSymbolOptions(symbol string, datatype DataType) bool {
+	return strings.TrimSpace(symbol) != "" && datatype.Valid()
+}
+
+func (o IntradayOptions) Valid() bool {
+	if !validSymbolOptions(o.Symbol, o.Datatype) || !o.Interval.Valid() {
+		return false
+	}
+	return o.Adjusted.Valid() && o.ExtendedHours.Valid() && o.OutputSize.Valid() && o.Entitlement.Valid()
+}
+
+func (o DailyOptions) Valid() bool {
+	return validSymbolOptions(o.Symbol, o.Datatype) && o.OutputSize.Valid() && o.Entitlement.Valid()
+}
+
+func (o DailyAdjustedOptions) Valid() bool {
+	return validSymbolOptions(o.Symbol, o.Datatype) && o.OutputSize.Valid() && o.Entitlement.Valid()
+}
+
+func (o WeeklyOptions) Valid() bool {
+	return validSymbolOptions(o.Symbol, o.Datatype) && o.Entitlement.Valid()
+}
+
+func (o WeeklyAdjustedOptions) Valid() bool {
+	return validSymbolOptions(o.Symbol, o.Datatype) && o.Entitlement.Valid()
+}
+
+func (o MonthlyOptions) Valid() bool {
+	return validSymbolOptions(o.Symbol, o.Datatype) && o.Entitlement.Valid()
+}
+
+func (o MonthlyAdjustedOptions) Valid() bool {
+	return validSymbolOptions(o.Symbol, o.Datatype) && o.Entitlement.Valid()
+}
+
+func (o QuoteOptions) Valid() bool {
+	return validSymbolOptions(o.Symbol, o.Datatype) && o.Entitlement.Valid()
+}
+
+func (o IntradayBackfillOptions) Valid() bool {
+	if strings.TrimSpace(o.Symbol) == "" || !o.Interval.Valid() || !o.Adjusted.Valid() || !o.ExtendedHours.Valid() || !o.Entitlement.Valid() {
+		return false
+	}
+
+	from, err := parseIntradayMonth(o.From)
+	if err != nil {
+		return false
+	}
+
+	to, err := parseIntradayMonth(o.To)
+	if err != nil {
+		return false
+	}
+
+	return !to.Before(from)
+}
+
+func parseIntradayMonth(month string) (time.Time, error) {
+	//Check for YYYY-MM
+	monthRegex := regexp.MustCompile(`^\d{4}-\d{2}$`)
+	if !monthRegex.MatchString(month) {
+		return time.Time{}, InValidInputError
+	}
+
+	monthValue, err := time.Parse("2006-01", month)
+	if err != nil {
+		return time.Time{}, InValidInputError
+	}
+
+	if monthValue.Before(earliestIntradayMonth) {
+		return time.Time{}, InValidInputError
+	}
+	return monthValue, nil
+}
+
+var earliestIntradayMonth = time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+
+type IntradayBackfillOptions struct {
+	Symbol        string
+	Interval      Interval
+	From          string
+	To            string
+	Adjusted      BoolString
+	ExtendedHours BoolString
+	Entitlement   Entitlement
+	Progress      func(month string, completed int, total int)
+}
+
+type IntradayOptions struct {
+	Symbol        string      `url:"symbol"`
+	Interval      Interval    `url:"interval"`
+	Adjusted      BoolString  `url:"adjusted, omitempty"`
+	ExtendedHours BoolString  `url:"extended_hours, omitempty"`
+	Month         string      `url:"month, omitempty"`
+	OutputSize    OutputSize  `url:"outputsize, omitempty"`
+	Datatype      DataType    `url:"datatype, omitempty"`
+	Entitlement   Entitlement `url:"entitlement, omitempty"`
+}
+
+type DailyOptions struct {
+	Symbol      string      `url:"symbol"`
+	OutputSize  OutputSize  `url:"outputsize, omitempty"`
+	Datatype    DataType    `url:"datatype, omitempty"`
+	Entitlement Entitlement `url:"entitlement, omitempty"`
+}
+
+type DailyAdjustedOptions struct {
+	Symbol      string      `url:"symbol"`
+	OutputSize  OutputSize  `url:"outputsize, omitempty"`
+	Datatype    DataType    `url:"datatype, omitempty"`
+	Entitlement Entitlement `url:"entitlement, omitempty"`
+}
+
+type WeeklyOptions struct {
+	Symbol      string      `url:"symbol"`
+	Datatype    DataType    `url:"datatype, omitempty"`
+	Entitlement Entitlement `url:"entitlement, omitempty"`
+}
+
+type WeeklyAdjustedOptions struct {
+	Symbol      string      `url:"symbol"`
+	Datatype    DataType    `url:"datatype, omitempty"`
+	Entitlement Entitlement `url:"entitlement, omitempty"`
+}
+
+type MonthlyOptions struct {
+	Symbol      string      `url:"symbol"`
+	Datatype    DataType    `url:"datatype, omitempty"`
+	Entitlement Entitlement `url:"entitlement, omitempty"`
+}
+
+type MonthlyAdjustedOptions struct {
+	Symbol      string      `url:"symbol"`
+	Datatype    DataType    `url:"datatype, omitempty"`
+	Entitlement Entitlement `url:"entitlement, omitempty"`
+}
+
+type QuoteOptions struct {
+	Symbol      string      `url:"symbol"`
+	Datatype    DataType    `url:"datatype, omitempty"`
+	Entitlement Entitlement `url:"entitlement, omitempty"`
+}
+
+type IntradayResponse struct {
+	MetaData    *MetaData
+	TimeSeries  map[string]*CoreStockData
+	Entitlement Entitlement
+}
+
+func (r *IntradayResponse) UnmarshalJSON(data []byte) error {
+	var raw timeSeriesJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*r = IntradayResponse{MetaData: raw.MetaData, TimeSeries: raw.TimeSeries}
+	return nil
+}
+
+type DailyResponse struct {
+	MetaData    *MetaData                 `json:"Meta Data,omitempty"`
+	TimeSeries  map[string]*CoreStockData `json:"Time Series (Daily),omitempty"`
+	Entitlement Entitlement               `json:"-"`
+}
+
+type DailyAdjustedResponse struct {
+	MetaData    *MetaData                 `json:"Meta Data,omitempty"`
+	TimeSeries  map[string]*CoreStockData `json:"Time Series (Daily),omitempty"`
+	Entitlement Entitlement               `json:"-"`
+}
+
+type WeeklyResponse struct {
+	MetaData    *MetaData                 `json:"Meta Data,omitempty"`
+	TimeSeries  map[string]*CoreStockData `json:"Weekly Time Series,omitempty"`
+	Entitlement Entitlement               `json:"-"`
+}
+
+type WeeklyAdjustedResponse struct {
+	MetaData    *MetaData                 `json:"Meta Data,omitempty"`
+	TimeSeries  map[string]*CoreStockData `json:"Weekly Adjusted Time Series,omitempty"`
+	Entitlement Entitlement               `json:"-"`
+}
+
+type MonthlyResponse struct {
+	MetaData    *MetaData                 `json:"Meta Data,omitempty"`
+	TimeSeries  map[string]*CoreStockData `json:"Monthly Time Series,omitempty"`
+	Entitlement Entitlement               `json:"-"`
+}
+
+type MonthlyAdjustedResponse struct {
+	MetaData    *MetaData                 `json:"Meta Data,omitempty"`
+	TimeSeries  map[string]*CoreStockData `json:"Monthly Adjusted Time Series,omitempty"`
+	Entitlement Entitlement               `json:"-"`
+}
+
+type QuoteResponse struct {
+	Quote       *GlobalQuote `json:"Global Quote,omitempty"`
+	Entitlement Entitlement  `json:"-"`
+}
+
+func (r *QuoteResponse) UnmarshalJSON(data []byte) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	for key, value := range fields {
+		if !strings.HasPrefix(key, "Global Quote") {
+			continue
+		}
+
+		var quote GlobalQuote
+		if err := json.Unmarshal(value, &quote); err != nil {
+			return err
+		}
+		// e.g. "Global Quote - DATA DELAYED BY 15 MINUTES"
+		quote.Entitlement = entitlementMarker(key)
+		r.Quote = &quote
+		r.Entitlement = quote.Entitlement
+	}
+	return nil
+}
+
+func metaDataTimeZone(metaData *MetaData) *string {
+	if metaData == nil {
+		return nil
+	}
+	return metaData.TimeZone
+}
+
+func (r *IntradayResponse) Bars() ([]Bar, error) {
+	return seriesBars(r.TimeSeries, metaDataTimeZone(r.MetaData))
+}
+
+func (r *DailyResponse) Bars() ([]Bar, error) {
+	return seriesBars(r.TimeSeries, metaDataTimeZone(r.MetaData))
+}
+
+func (r *DailyAdjustedResponse) Bars() ([]Bar, error) {
+	return seriesBars(r.TimeSeries, metaDataTimeZone(r.MetaData))
+}
+
+func (r *WeeklyResponse) Bars() ([]Bar, error) {
+	return seriesBars(r.TimeSeries, metaDataTimeZone(r.MetaData))
+}
+
+func (r *WeeklyAdjustedResponse) Bars() ([]Bar, error) {
+	return seriesBars(r.TimeSeries, metaDataTimeZone(r.MetaData))
+}
+
+func (r *MonthlyResponse) Bars() ([]Bar, error) {
+	return seriesBars(r.TimeSeries, metaDataTimeZone(r.MetaData))
+}
+
+func (r *MonthlyAdjustedResponse) Bars() ([]Bar, error) {
+	return seriesBars(r.TimeSeries, metaDataTimeZone(r.MetaData))
+}
+
+func (c *Client) GetIntraday(ctx context.Context, options *IntradayOptions) (*IntradayResponse, error) {
+	if options == nil || !options.Valid() || !c.Entitlement.Valid() {
+		return nil, InValidInputError
+	}
+
+	res, err := c.getTimeSeries(ctx, "TIME_SERIES_INTRADAY", options, options.Datatype, options.Entitlement)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get intraday time series: %w", err)
+	}
+	return &IntradayResponse{MetaData: res.MetaData, TimeSeries: res.TimeSeries, Entitlement: res.Entitlement}, nil
+}
+
+func (c *Client) GetDaily(ctx context.Context, options *DailyOptions) (*DailyResponse, error) {
+	if options == nil || !options.Valid() || !c.Entitlement.Valid() {
+		return nil, InValidInputError
+	}
+
+	res, err := c.getTimeSeries(ctx, "TIME_SERIES_DAILY", options, options.Datatype, options.Entitlement)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get daily time series: %w", err)
+	}
+	return &DailyResponse{MetaData: res.MetaData, TimeSeries: res.TimeSeries, Entitlement: res.Entitlement}, nil
+}
+
+func (c *Client) GetDailyAdjusted(ctx context.Context, options *DailyAdjustedOptions) (*DailyAdjustedResponse, error) {
+	if options == nil || !options.Valid() || !c.Entitlement.Valid() {
+		return nil, InValidInputError
+	}
+
+	res, err := c.getTimeSeries(ctx, "TIME_SERIES_DAILY_ADJUSTED", options, options.Datatype, options.Entitlement)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get daily adjusted time series: %w", err)
+	}
+	return &DailyAdjustedResponse{MetaData: res.MetaData, TimeSeries: res.TimeSeries, Entitlement: res.Entitlement}, nil
+}
+
+func (c *Client) GetWeekly(ctx context.Context, options *WeeklyOptions) (*WeeklyResponse, error) {
+	if options == nil || !options.Valid() || !c.Entitlement.Valid() {
+		return nil, InValidInputError
+	}
+
+	res, err := c.getTimeSeries(ctx, "TIME_SERIES_WEEKLY", options, options.Datatype, options.Entitlement)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get weekly time series: %w", err)
+	}
+	return &WeeklyResponse{MetaData: res.MetaData, TimeSeries: res.TimeSeries, Entitlement: res.Entitlement}, nil
+}
+
+func (c *Client) GetWeeklyAdjusted(ctx context.Context, options *WeeklyAdjustedOptions) (*WeeklyAdjustedResponse, error) {
+	if options == nil || !options.Valid() || !c.Entitlement.Valid() {
+		return nil, InValidInputError
+	}
+
+	res, err := c.getTimeSeries(ctx, "TIME_SERIES_WEEKLY_ADJUSTED", options, options.Datatype, options.Entitlement)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get weekly adjusted time series: %w", err)
+	}
+	return &WeeklyAdjustedResponse{MetaData: res.MetaData, TimeSeries: res.TimeSeries, Entitlement: res.Entitlement}, nil
+}
+
+func (c *Client) GetMonthly(ctx context.Context, options *MonthlyOptions) (*MonthlyResponse, error) {
+	if options == nil || !options.Valid() || !c.Entitlement.Valid() {
+		return nil, InValidInputError
+	}
+
+	res, err := c.getTimeSeries(ctx, "TIME_SERIES_MONTHLY", options, options.Datatype, options.Entitlement)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get monthly time series: %w", err)
+	}
+	return &MonthlyResponse{MetaData: res.MetaData, TimeSeries: res.TimeSeries, Entitlement: res.Entitlement}, nil
+}
+
+func (c *Client) GetMonthlyAdjusted(ctx context.Context, options *MonthlyAdjustedOptions) (*MonthlyAdjustedResponse, error) {
+	if options == nil || !options.Valid() || !c.Entitlement.Valid() {
+		return nil, InValidInputError
+	}
+
+	res, err := c.getTimeSeries(ctx, "TIME_SERIES_MONTHLY_ADJUSTED", options, options.Datatype, options.Entitlement)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get monthly adjusted time series: %w", err)
+	}
+	return &MonthlyAdjustedResponse{MetaData: res.MetaData, TimeSeries: res.TimeSeries, Entitlement: res.Entitlement}, nil
+}
+
+func (c *Client) GetQuote(ctx context.Context, options *QuoteOptions) (*QuoteResponse, error) {
+	if options == nil || !options.Valid() || !c.Entitlement.Valid() {
+		return nil, InValidInputError
+	}
+
+	quote, err := c.getQuote(ctx, options, options.Datatype, options.Entitlement)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get quote: %w", err)
+	}
+	res := QuoteResponse{Quote: quote}
+	if quote != nil {
+		res.Entitlement = quote.Entitlement
+	}
+	return &res, nil
+}
+
+func (c *Client) BackfillIntraday(ctx context.Context, options *IntradayBackfillOptions) ([]Bar, error) {
+	if options == nil || !options.Valid() {
+		return nil, InValidInputError
+	}
+
+	from, _ := parseIntradayMonth(options.From)
+	to, _ := parseIntradayMonth(options.To)
+
+	var months []string
+	for month := from; !month.After(to); month = month.AddDate(0, 1, 0) {
+		months = append(months, month.Format("2006-01"))
+	}
+
+	barsByTime := make(map[int64]Bar)
+	for i, month := range months {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		res, err := c.GetIntraday(ctx, &IntradayOptions{
+			Symbol:        options.Symbol,
+			Interval:      options.Interval,
+			Adjusted:      options.Adjusted,
+			ExtendedHours: options.ExtendedHours,
+			Entitlement:   options.Entitlement,
+			Month:         month,
+			OutputSize:    "full",
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to backfill intraday month %s: %w", month, err)
+		}
+
+		bars, err := res.Bars()
+		if err != nil {
+			return nil, fmt.Errorf("failed to backfill intraday month %s: %w", month, err)
+		}
+
+		for _, bar := range bars {
+			barsByTime[bar.Time.UnixNano()] = bar
+		}
+
+		if options.Progress != nil {
+			options.Progress(month, i+1, len(months))
+		}
+	}
+
+	merged := make([]Bar, 0, len(barsByTime))
+	for _, bar := range barsByTime {
+		merged = append(merged, bar)
+	}
+
+	sort.Slice(merged, func(i, j int) bool {
+		return merged[i].Time.Before(merged[j].Time)
+	})
+	return merged, nil
+}
+
+type timeSeriesJSON struct {
+	MetaData   *MetaData
+	TimeSeries map[string]*CoreStockData
+}
+
+func (t *timeSeriesJSON) UnmarshalJSON(data []byte) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	for key, value := range fields {
+		switch {
+		case key == "Meta Data":
+			if err := json.Unmarshal(value, &t.MetaData); err != nil {
+				return err
+			}
+		case strings.Contains(key, "Time Series"):
+			if err := json.Unmarshal(value, &t.TimeSeries); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+type timeSeriesResult struct {
+	MetaData    *MetaData
+	TimeSeries  map[string]*CoreStockData
+	Entitlement Entitlement
+}
+
+func (c *Client) seriesQuery(function Function, options interface{}, entitlement Entitlement) string {
+	query := fmt.Sprintf("function=%s&%s", function, c.buildQuery(options))
+	if entitlement == "" && c.Entitlement != "" {
+		query += "&entitlement=" + string(c.Entitlement)
+	}
+	return query
+}
+
+func (c *Client) getTimeSeries(ctx context.Context, function Function, options interface{}, datatype DataType, entitlement Entitlement) (*timeSeriesResult, error) {
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s%s", c.BaseURL, c.seriesQuery(function, options, entitlement)), nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+
+	if strings.ToLower(string(datatype)) != "csv" {
+		var res timeSeriesJSON
+		if err = c.doJSONRequest(req, &res); err != nil {
+			return nil, err
+		}
+		return &timeSeriesResult{MetaData: res.MetaData, TimeSeries: res.TimeSeries, Entitlement: res.MetaData.Entitlement()}, nil
+	}
+
+	var rows []coreStockCSVRow
+	if err = c.doCSVRequest(req, &rows); err != nil {
+		return nil, err
+	}
+
+	adjusted := strings.HasSuffix(strings.ToUpper(string(function)), "_ADJUSTED")
+	series := make(map[string]*CoreStockData, len(rows))
+	for i := range rows {
+		data := rows[i].CoreStockData
+		if adjusted {
+			data.VolumeForAdjustedCall, data.Volume = data.Volume, nil
+		}
+		series[rows[i].Timestamp] = &data
+	}
+	return &timeSeriesResult{TimeSeries: series}, nil
+}
+
+func (c *Client) getQuote(ctx context.Context, options interface{}, datatype DataType, entitlement Entitlement) (*GlobalQuote, error) {
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s%s", c.BaseURL, c.seriesQuery("GLOBAL_QUOTE", options, entitlement)), nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+
+	if strings.ToLower(string(datatype)) != "csv" {
+		var res QuoteResponse
+		if err = c.doJSONRequest(req, &res); err != nil {
+			return nil, err
+		}
+		return res.Quote, nil
+	}
+
+	var quotes []GlobalQuote
+	if err = c.doCSVRequest(req, &quotes); err != nil {
+		return nil, err
+	}
+	if len(quotes) == 0 {
+		return nil, nil
+	}
+	// CSV quotes carry no entitlement marker, so the entitlement stays unknown
+	return &quotes[0], nil
+}